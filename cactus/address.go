@@ -1,9 +1,11 @@
 package cactus
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"net/url"
 )
 
 const (
@@ -16,11 +18,14 @@ const (
 
 // ApplyNewAddressResp is the response of ApplyNewAddress
 // Data is an array of addresses
-type ApplyNewAddressResp struct {
-	Code       int      `json:"code"`
-	Message    string   `json:"message"`
-	Data       []string `json:"data"`
-	Successful bool     `json:"successful"`
+type ApplyNewAddressResp = Response[[]string]
+
+type applyNewAddressReq struct {
+	AddressNum  int                   `json:"address_num"`
+	AddressType string                `json:"address_type"`
+	BId         string                `json:"b_id"`
+	CoinName    constants.CactusToken `json:"coin_name,omitempty"`
+	WalletCode  string                `json:"wallet_code"`
 }
 
 // ApplyNewAddress applies new address for a wallet
@@ -30,16 +35,20 @@ type ApplyNewAddressResp struct {
 // addressNum: number of addresses to apply
 // addressType: address type, only accept "NORMAL_ADDRESS"
 func (c *Cactus) ApplyNewAddress(bId string, walletCode string, coinName constants.CactusToken, addressNum int, addressType string) (*ApplyNewAddressResp, error) {
-	req := map[string]interface{}{
-		"address_num":  addressNum,
-		"address_type": addressType,
-		"b_id":         bId,
-		"wallet_code":  walletCode,
-	}
-	if coinName != "" {
-		req["coin_name"] = string(coinName)
-	}
-	resp, err := c.post(ApplyNewAddressUrl, req)
+	return c.ApplyNewAddressWithContext(context.Background(), bId, walletCode, coinName, addressNum, addressType)
+}
+
+// ApplyNewAddressWithContext is ApplyNewAddress with a caller supplied context.
+func (c *Cactus) ApplyNewAddressWithContext(ctx context.Context, bId string, walletCode string, coinName constants.CactusToken, addressNum int, addressType string) (*ApplyNewAddressResp, error) {
+	req := applyNewAddressReq{
+		AddressNum:  addressNum,
+		AddressType: addressType,
+		BId:         bId,
+		CoinName:    coinName,
+		WalletCode:  walletCode,
+	}
+	path := fmt.Sprintf(ApplyNewAddressUrl, bId, walletCode)
+	resp, err := c.post(ctx, path, req)
 	if err != nil {
 		return nil, err
 	}
@@ -51,26 +60,7 @@ func (c *Cactus) ApplyNewAddress(bId string, walletCode string, coinName constan
 	return &applyNewAddressResp, nil
 }
 
-type GetSingleAddressResp struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	Successful bool   `json:"successful"`
-	Data       struct {
-		Address          string      `json:"address"`
-		AddressStorage   string      `json:"address_storage"`
-		AddressType      string      `json:"address_type"`
-		AvailableAmount  interface{} `json:"available_amount"`
-		CoinName         string      `json:"coin_name"`
-		BchAddressFormat interface{} `json:"bch_address_format"`
-		BId              string      `json:"b_id"`
-		Description      string      `json:"description"`
-		DomainId         string      `json:"domain_id"`
-		FreezeAmount     interface{} `json:"freeze_amount"`
-		TotalAmount      int         `json:"total_amount"`
-		WalletCode       string      `json:"wallet_code"`
-		WalletType       string      `json:"wallet_type"`
-	} `json:"data"`
-}
+type GetSingleAddressResp = Response[Address]
 
 // GetSingleAddress gets the single address info
 // bId: business id
@@ -78,12 +68,17 @@ type GetSingleAddressResp struct {
 // coinName: coin name, optional
 // address: address
 func (c *Cactus) GetSingleAddress(bId string, walletCode string, coinName constants.CactusToken, address string) (*GetSingleAddressResp, error) {
-	query := map[string]string{}
+	return c.GetSingleAddressWithContext(context.Background(), bId, walletCode, coinName, address)
+}
+
+// GetSingleAddressWithContext is GetSingleAddress with a caller supplied context.
+func (c *Cactus) GetSingleAddressWithContext(ctx context.Context, bId string, walletCode string, coinName constants.CactusToken, address string) (*GetSingleAddressResp, error) {
+	query := url.Values{}
 	if coinName != "" {
-		query["coin_name"] = string(coinName)
+		query.Set("coin_name", string(coinName))
 	}
-	url := fmt.Sprintf(GetSingleAddressUrl, bId, walletCode, address)
-	resp, err := c.get(url, query)
+	path := fmt.Sprintf(GetSingleAddressUrl, bId, walletCode, address)
+	resp, err := c.get(ctx, path, query)
 	if err != nil {
 		return nil, err
 	}
@@ -95,31 +90,7 @@ func (c *Cactus) GetSingleAddress(bId string, walletCode string, coinName consta
 	return &getSingleAddressResp, nil
 }
 
-type GetAddressListResp struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	Successful bool   `json:"successful"`
-	Data       struct {
-		Limit  int `json:"limit"`
-		Offset int `json:"offset"`
-		Total  int `json:"total"`
-		List   []struct {
-			Address          string      `json:"address"`
-			AddressStorage   string      `json:"address_storage"`
-			AddressType      string      `json:"address_type"`
-			AvailableAmount  interface{} `json:"available_amount"`
-			CoinName         string      `json:"coin_name"`
-			BchAddressFormat interface{} `json:"bch_address_format"`
-			BId              string      `json:"b_id"`
-			Description      string      `json:"description"`
-			DomainId         string      `json:"domain_id"`
-			FreezeAmount     interface{} `json:"freeze_amount"`
-			TotalAmount      int         `json:"total_amount"`
-			WalletCode       string      `json:"wallet_code"`
-			WalletType       string      `json:"wallet_type"`
-		} `json:"list"`
-	} `json:"data"`
-}
+type GetAddressListResp = Response[Page[Address]]
 
 // GetAddressList gets the address list
 // bId: business id
@@ -130,24 +101,29 @@ type GetAddressListResp struct {
 // offset: offset, optional, default 0
 // limit: limit, optional, default 10
 func (c *Cactus) GetAddressList(bId string, walletCode string, coinName constants.CactusToken, hideNoCoinAddress bool, keyword string, offset int, limit int) (*GetAddressListResp, error) {
-	query := map[string]string{}
+	return c.GetAddressListWithContext(context.Background(), bId, walletCode, coinName, hideNoCoinAddress, keyword, offset, limit)
+}
+
+// GetAddressListWithContext is GetAddressList with a caller supplied context.
+func (c *Cactus) GetAddressListWithContext(ctx context.Context, bId string, walletCode string, coinName constants.CactusToken, hideNoCoinAddress bool, keyword string, offset int, limit int) (*GetAddressListResp, error) {
+	query := url.Values{}
 	if hideNoCoinAddress {
-		query["hide_no_coin_address"] = "true"
+		query.Set("hide_no_coin_address", "true")
 	}
 	if offset != 0 {
-		query["offset"] = fmt.Sprintf("%d", offset)
+		query.Set("offset", fmt.Sprintf("%d", offset))
 	}
 	if limit != 0 {
-		query["limit"] = fmt.Sprintf("%d", limit)
+		query.Set("limit", fmt.Sprintf("%d", limit))
 	}
 	if coinName != "" {
-		query["coin_name"] = string(coinName)
+		query.Set("coin_name", string(coinName))
 	}
 	if keyword != "" {
-		query["keyword"] = keyword
+		query.Set("keyword", keyword)
 	}
-	url := fmt.Sprintf(GetAddressListUrl, bId, walletCode)
-	resp, err := c.get(url, query)
+	path := fmt.Sprintf(GetAddressListUrl, bId, walletCode)
+	resp, err := c.get(ctx, path, query)
 	if err != nil {
 		return nil, err
 	}
@@ -159,11 +135,7 @@ func (c *Cactus) GetAddressList(bId string, walletCode string, coinName constant
 	return &getAddressListResp, nil
 }
 
-type EditAccountDescriptionResp struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	Successful bool   `json:"successful"`
-}
+type EditAccountDescriptionResp = Response[NoData]
 
 // EditAddressDescription edits the address description
 // bId: business id
@@ -171,11 +143,16 @@ type EditAccountDescriptionResp struct {
 // address: address
 // description: description
 func (c *Cactus) EditAddressDescription(bId string, walletCode string, address string, description string) (*EditAccountDescriptionResp, error) {
+	return c.EditAddressDescriptionWithContext(context.Background(), bId, walletCode, address, description)
+}
+
+// EditAddressDescriptionWithContext is EditAddressDescription with a caller supplied context.
+func (c *Cactus) EditAddressDescriptionWithContext(ctx context.Context, bId string, walletCode string, address string, description string) (*EditAccountDescriptionResp, error) {
 	url := fmt.Sprintf(EditAddressDescriptionUrl, bId, walletCode, address)
-	req := map[string]interface{}{
-		"description": description,
-	}
-	resp, err := c.post(url, req)
+	req := struct {
+		Description string `json:"description"`
+	}{description}
+	resp, err := c.post(ctx, url, req)
 	if err != nil {
 		return nil, err
 	}
@@ -189,22 +166,22 @@ func (c *Cactus) EditAddressDescription(bId string, walletCode string, address s
 
 // VerifyAddressResp verifies the address format
 // data: bad address list
-type VerifyAddressResp struct {
-	Code       int      `json:"code"`
-	Message    string   `json:"message"`
-	Successful bool     `json:"successful"`
-	Data       []string `json:"data"`
-}
+type VerifyAddressResp = Response[[]string]
 
 // VerifyAddress verifies the address format
 // coinName: coin name
 // addresses: address list
 func (c *Cactus) VerifyAddress(coinName constants.CactusToken, addresses []string) (*VerifyAddressResp, error) {
-	params := map[string]interface{}{
-		"coin_name": coinName,
-		"addresses": addresses,
-	}
-	resp, err := c.post(VerifyAddressFormat, params)
+	return c.VerifyAddressWithContext(context.Background(), coinName, addresses)
+}
+
+// VerifyAddressWithContext is VerifyAddress with a caller supplied context.
+func (c *Cactus) VerifyAddressWithContext(ctx context.Context, coinName constants.CactusToken, addresses []string) (*VerifyAddressResp, error) {
+	params := struct {
+		Addresses []string              `json:"addresses"`
+		CoinName  constants.CactusToken `json:"coin_name"`
+	}{addresses, coinName}
+	resp, err := c.post(ctx, VerifyAddressFormat, params)
 	if err != nil {
 		return nil, err
 	}
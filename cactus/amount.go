@@ -0,0 +1,122 @@
+package cactus
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Amount is an integer amount in the smallest unit of a coin, e.g. wei or satoshi
+// It has arbitrary precision, so 18 decimal token balances do not overflow. The zero value is 0.
+// Amount is immutable, arithmetic returns a new value.
+//
+// It is encoded as a JSON number and decoded from a JSON number or string without going through float64.
+type Amount struct {
+	i *big.Int
+}
+
+// NewAmount returns the amount x
+func NewAmount(x int64) Amount {
+	return Amount{i: big.NewInt(x)}
+}
+
+// NewAmountFromBig returns the amount x, x is copied
+func NewAmountFromBig(x *big.Int) Amount {
+	if x == nil {
+		return Amount{}
+	}
+	return Amount{i: new(big.Int).Set(x)}
+}
+
+// ParseAmount parses a base 10 integer
+// Decimal and exponent forms are accepted as long as they denote an integer, like "1.0" or "1e18".
+func ParseAmount(s string) (Amount, error) {
+	if i, ok := new(big.Int).SetString(s, 10); ok {
+		return Amount{i: i}, nil
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Amount{}, fmt.Errorf("cactus: invalid amount %q", s)
+	}
+	if !r.IsInt() {
+		return Amount{}, fmt.Errorf("cactus: amount %q is not an integer", s)
+	}
+	return Amount{i: new(big.Int).Set(r.Num())}, nil
+}
+
+// MustParseAmount is ParseAmount panicking on error, for constants
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (a Amount) big() *big.Int {
+	if a.i == nil {
+		return new(big.Int)
+	}
+	return a.i
+}
+
+// Big returns the amount as a new big.Int
+func (a Amount) Big() *big.Int {
+	return new(big.Int).Set(a.big())
+}
+
+// Int64 returns the amount as int64, ok is false if it does not fit
+func (a Amount) Int64() (v int64, ok bool) {
+	return a.big().Int64(), a.big().IsInt64()
+}
+
+// Sign returns -1, 0 or 1 for negative, zero and positive amounts
+func (a Amount) Sign() int {
+	return a.big().Sign()
+}
+
+// IsZero reports whether the amount is 0
+func (a Amount) IsZero() bool {
+	return a.Sign() == 0
+}
+
+// Cmp compares a and b, returns -1, 0 or 1
+func (a Amount) Cmp(b Amount) int {
+	return a.big().Cmp(b.big())
+}
+
+// Add returns a + b
+func (a Amount) Add(b Amount) Amount {
+	return Amount{i: new(big.Int).Add(a.big(), b.big())}
+}
+
+// Sub returns a - b
+func (a Amount) Sub(b Amount) Amount {
+	return Amount{i: new(big.Int).Sub(a.big(), b.big())}
+}
+
+// String returns the amount in base 10
+func (a Amount) String() string {
+	return a.big().String()
+}
+
+// MarshalJSON encodes the amount as a JSON number
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return a.big().Append(nil, 10), nil
+}
+
+// UnmarshalJSON decodes a JSON number or string, null and "" decode to 0
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	if len(data) == 0 || string(data) == "null" {
+		*a = Amount{}
+		return nil
+	}
+	parsed, err := ParseAmount(string(data))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
@@ -0,0 +1,64 @@
+package cactus
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAmountJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{`123`, "123", false},
+		{`"123"`, "123", false},
+		{`-400`, "-400", false},
+		{`115792089237316195423570985008687907853269984665640564039457584007913129639935`, "115792089237316195423570985008687907853269984665640564039457584007913129639935", false},
+		{`"1000000000000000000000001"`, "1000000000000000000000001", false},
+		{`1e21`, "1000000000000000000000", false},
+		{`"2.000"`, "2", false},
+		{`null`, "0", false},
+		{`""`, "0", false},
+		{`1.5`, "", true},
+		{`"abc"`, "", true},
+		{`true`, "", true},
+	}
+	for _, tt := range tests {
+		var a Amount
+		err := json.Unmarshal([]byte(tt.in), &a)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && a.String() != tt.want {
+			t.Errorf("Unmarshal(%s) = %s, want %s", tt.in, a, tt.want)
+		}
+	}
+
+	out, err := json.Marshal(struct {
+		A Amount `json:"a"`
+		Z Amount `json:"z"`
+	}{A: MustParseAmount("1000000000000000000000001")})
+	if err != nil || string(out) != `{"a":1000000000000000000000001,"z":0}` {
+		t.Errorf("Marshal() = %s, %v", out, err)
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a := NewAmount(5)
+	b := MustParseAmount("9223372036854775807")
+	sum := a.Add(b)
+	if sum.String() != "9223372036854775812" || a.String() != "5" {
+		t.Errorf("Add() = %s, a = %s", sum, a)
+	}
+	if _, ok := sum.Int64(); ok {
+		t.Error("Int64() ok for an amount above int64")
+	}
+	if v, ok := sum.Sub(b).Int64(); !ok || v != 5 {
+		t.Errorf("Sub().Int64() = %d, %v", v, ok)
+	}
+	if a.Cmp(b) != -1 || !(Amount{}).IsZero() || NewAmount(-1).Sign() != -1 {
+		t.Error("unexpected comparison result")
+	}
+}
@@ -2,13 +2,22 @@ package cactus
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/DenrianWeiss/cactus-wallet-sdk/utils"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Cactus struct {
@@ -16,14 +25,42 @@ type Cactus struct {
 	XApiKey    string
 	ApiKeyID   string
 	PrivateKey *ecdsa.PrivateKey
+	// Signer signs requests, takes precedence over PrivateKey so the key can stay in an HSM or KMS
+	Signer     utils.Signer
 	HttpClient *http.Client
-	LogLevel   int
+	// Deprecated: LogLevel is only read by NewCactus, use Logger instead
+	LogLevel int
+	// Logger receives structured request logs, nil discards them
+	Logger Logger
+	// Redaction selects what is masked in logs, nil uses DefaultRedaction
+	Redaction *Redaction
+	// Retry controls resending of failed calls, nil disables retries
+	Retry *RetryPolicy
+	// Limiter throttles outgoing calls, nil disables client side throttling
+	Limiter *Limiter
+	// Clock is the local clock requests are dated with, nil uses time.Now
+	Clock func() time.Time
+	// NonceSource returns the x-api-nonce of each request, nil uses utils.GenerateUuid
+	NonceSource func() string
+	// CalibrateSkew corrects the Date of later requests by the skew measured on cactus responses,
+	// see ClockSkew
+	CalibrateSkew bool
+
+	skew         atomic.Int64
+	registryOnce sync.Once
+	registry     *Registry
 }
 
 func NewCactus(baseUri string, xApiKey string, apiKeyId string, privateKey *ecdsa.PrivateKey, client *http.Client, logLevel int) *Cactus {
 	if client == nil {
 		client = http.DefaultClient
 	}
+	// A negative logLevel silences the client, otherwise logs go to slog.Default(), which drops the
+	// debug level per call entries unless configured to keep them
+	var logger Logger = NopLogger{}
+	if logLevel >= 0 {
+		logger = NewSlogLogger(nil)
+	}
 	return &Cactus{
 		BaseUri:    baseUri,
 		XApiKey:    xApiKey,
@@ -31,100 +68,194 @@ func NewCactus(baseUri string, xApiKey string, apiKeyId string, privateKey *ecds
 		PrivateKey: privateKey,
 		HttpClient: client,
 		LogLevel:   logLevel,
+		Logger:     logger,
 	}
 }
 
-func (c *Cactus) post(path string, body map[string]interface{}) ([]byte, error) {
-	// Encode body
-	bodyBytes, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
+// NewCactusWithSigner creates a client whose requests are signed by signer instead of an in memory key
+func NewCactusWithSigner(baseUri string, xApiKey string, apiKeyId string, signer utils.Signer, client *http.Client, logLevel int) *Cactus {
+	c := NewCactus(baseUri, xApiKey, apiKeyId, nil, client, logLevel)
+	c.Signer = signer
+	return c
+}
+
+func (c *Cactus) signer() utils.Signer {
+	if c.Signer != nil {
+		return c.Signer
 	}
-	// Hash Request Body
-	bodyHash := sha256.Sum256(bodyBytes)
-	bodyHashBase := base64.StdEncoding.EncodeToString(bodyHash[:])
+	if c.PrivateKey != nil {
+		return utils.NewECDSASigner(c.PrivateKey)
+	}
+	return nil
+}
 
-	// Generate sign string
-	/// Get TimeStamp
-	currentTime := utils.GetCurrentGmtTime()
-	/// Sign
-	nonce := utils.GenerateUuid()
-	signString := utils.GenerateSignString(http.MethodPost, bodyBytes, c.XApiKey, nonce, path, "", currentTime)
-	// Sign
-	header, err := utils.GenerateAuthorizationHeader([]byte(signString), c.ApiKeyID, c.PrivateKey)
+// post encodes body once, the signed hash and the sent request use the same bytes
+// body: a typed request struct, or already encoded []byte
+func (c *Cactus) post(ctx context.Context, path string, body any) ([]byte, error) {
+	bodyBytes, err := encodeBody(body)
 	if err != nil {
 		return nil, err
 	}
-	// Assemble Req
-	req, err := http.NewRequest(http.MethodPost, c.BaseUri+path, bytes.NewReader(bodyBytes))
-	// Add headers
-	req.Header.Add("Accept", utils.Accept)
-	req.Header.Add("Content-Type", utils.RequestContentType)
-	req.Header.Add("x-api-key", c.XApiKey)
-	req.Header.Add("x-api-nonce", nonce)
-	req.Header.Add("Content-SHA256", bodyHashBase)
-	req.Header.Add("Date", currentTime)
-	req.Header.Add("Authorization", header)
-	// Send request
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		c.Log(1, "Post Error"+err.Error())
-		return nil, err
+	return c.request(ctx, http.MethodPost, path, nil, bodyBytes)
+}
+
+func (c *Cactus) get(ctx context.Context, path string, params url.Values) ([]byte, error) {
+	return c.request(ctx, http.MethodGet, path, params, nil)
+}
+
+// Do signs and sends a request to any cactus endpoint, including ones this package does not wrap yet
+// method: GET, POST, PUT or DELETE
+// path: the request path without query, e.g. "/custody/v1/api/projects/{b_id}/wallets/create"
+// query: url parameters, optional, signed the same way as by the wrapped endpoints
+// body: request body, optional, []byte and json.RawMessage are sent as is, anything else is json encoded
+// out: optional, the json response, including the code/message/data envelope, is decoded into it
+// Failed responses are returned as *APIError. Only GET requests are retried under Retry.
+func (c *Cactus) Do(ctx context.Context, method string, path string, query url.Values, body any, out any) error {
+	switch method {
+	case http.MethodGet:
+		if body != nil {
+			return errors.New("cactus: GET requests can not carry a body")
+		}
+	case http.MethodPost, http.MethodPut, http.MethodDelete:
+	default:
+		return fmt.Errorf("cactus: unsupported method %q", method)
 	}
-	defer resp.Body.Close()
-	// Read response
-	all, err := io.ReadAll(resp.Body)
-	c.Log(0, string(all))
+	if !strings.HasPrefix(path, "/") || strings.ContainsAny(path, "?#") {
+		return fmt.Errorf("cactus: path %q must start with / and carry no query, pass parameters in query", path)
+	}
+	bodyBytes, err := encodeBody(body)
 	if err != nil {
-		c.Log(1, "Post Read Error"+err.Error())
-		return nil, err
+		return err
 	}
-	return all, nil
+	resp, err := c.request(ctx, method, path, query, bodyBytes)
+	if err != nil || out == nil {
+		return err
+	}
+	return json.Unmarshal(resp, out)
+}
+
+// encodeBody returns the bytes to send, nil means no body
+func encodeBody(body any) ([]byte, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return b, nil
+	case json.RawMessage:
+		return b, nil
+	}
+	return json.Marshal(body)
 }
 
-func (c *Cactus) get(path string, params map[string]string) ([]byte, error) {
-	// Encode url params
-	paramQ := utils.EncodeGetQuery(params)
-	paramEncode := utils.EncodeGetParams(params)
+// request encodes the query and sends the request, retrying GET requests under Retry
+func (c *Cactus) request(ctx context.Context, method string, path string, query url.Values, bodyBytes []byte) ([]byte, error) {
+	// Encode url params, the wire query and the signed params are built from the same canonical form
+	paramQ := utils.EncodeQuery(query)
+	paramEncode := utils.EncodeSignParams(query)
+	send := func() ([]byte, error) {
+		return c.send(ctx, method, path, paramQ, paramEncode, bodyBytes)
+	}
+	if method != http.MethodGet {
+		return send()
+	}
+	// GET is idempotent, so it is safe to resend
+	return c.withRetry(ctx, send)
+}
+
+// send signs and sends a single request, every call uses a fresh nonce and timestamp
+func (c *Cactus) send(ctx context.Context, method string, path string, paramQ string, paramEncode string, bodyBytes []byte) ([]byte, error) {
+	if c.Limiter != nil {
+		release, waited, err := c.Limiter.Wait(ctx, endpointGroupOf(method, path))
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		if waited >= time.Millisecond {
+			c.log(ctx, slog.LevelDebug, "cactus rate limiter delayed request",
+				slog.String("method", method), slog.String("path", c.redactPath(path)), slog.Duration("wait", waited))
+		}
+	}
 	// Generate sign string
 	/// Get TimeStamp
-	currentTime := utils.GetCurrentGmtTime()
+	currentTime := utils.FormatDate(c.now())
 	/// Sign
-	nonce := utils.GenerateUuid()
-	signString := utils.GenerateSignString(http.MethodGet, nil, c.XApiKey, nonce, path, paramEncode, currentTime)
-	// Add headers
-	header, err := utils.GenerateAuthorizationHeader([]byte(signString), c.ApiKeyID, c.PrivateKey)
+	nonce := c.nonce()
+	signString := utils.GenerateSignString(method, bodyBytes, c.XApiKey, nonce, path, paramEncode, currentTime)
+	// Sign
+	header, err := utils.GenerateAuthorizationHeaderWithSigner(ctx, []byte(signString), c.ApiKeyID, c.signer())
 	if err != nil {
-		c.Log(1, err.Error())
+		c.log(ctx, slog.LevelError, "cactus request signing failed", slog.String("method", method), slog.String("path", c.redactPath(path)), c.errorAttr(err))
 		return nil, err
 	}
 	// Assemble Req
-	req, err := http.NewRequest(http.MethodGet, c.BaseUri+path+"?"+paramQ, nil)
+	reqUrl := c.BaseUri + path
+	if paramQ != "" {
+		reqUrl += "?" + paramQ
+	}
+	var body io.Reader
+	if bodyBytes != nil {
+		body = bytes.NewReader(bodyBytes)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqUrl, body)
+	if err != nil {
+		return nil, err
+	}
+	// Add headers
 	req.Header.Add("Accept", utils.Accept)
 	req.Header.Add("Content-Type", utils.RequestContentType)
 	req.Header.Add("x-api-key", c.XApiKey)
 	req.Header.Add("x-api-nonce", nonce)
+	if bodyBytes != nil {
+		// Hash Request Body
+		bodyHash := sha256.Sum256(bodyBytes)
+		req.Header.Add("Content-SHA256", base64.StdEncoding.EncodeToString(bodyHash[:]))
+	}
 	req.Header.Add("Date", currentTime)
 	req.Header.Add("Authorization", header)
+	redaction := c.redaction()
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("path", c.redactPath(path)),
+		slog.String("nonce", nonce),
+	}
+	if paramQ != "" {
+		attrs = append(attrs, slog.String("query", redactQuery(paramQ, redaction)))
+	}
+	c.log(ctx, slog.LevelDebug, "cactus request", append(attrs,
+		slog.String("x-api-key", redactHeader(c.XApiKey, redaction.APIKey)),
+		slog.String("authorization", redactHeader(header, redaction.Authorization)),
+		slog.String("body", redactBody(bodyBytes, redaction)),
+	)...)
 	// Send request
+	start := time.Now()
+	sent := c.clock()
 	resp, err := c.HttpClient.Do(req)
 	if err != nil {
-		c.Log(1, err.Error())
+		c.log(ctx, slog.LevelError, "cactus request failed", append(attrs, slog.Duration("latency", time.Since(start)), c.errorAttr(err))...)
 		return nil, err
 	}
-	c.Log(0, resp.Status)
 	defer resp.Body.Close()
+	c.observeDate(ctx, resp.Header, sent, c.clock())
 	// Read response
 	all, err := io.ReadAll(resp.Body)
-	c.Log(0, string(all))
+	attrs = append(attrs, slog.Int("status", resp.StatusCode), slog.Duration("latency", time.Since(start)))
 	if err != nil {
+		c.log(ctx, slog.LevelError, "cactus response read failed", append(attrs, c.errorAttr(err))...)
 		return nil, err
 	}
-	return all, nil
-}
-
-func (c *Cactus) Log(level int, message string) {
-	if c.LogLevel >= level {
-		println(message)
+	code, err := checkResponse(resp.StatusCode, all, nonce, path)
+	attrs = append(attrs, slog.Int("code", code))
+	if err != nil {
+		if apiErr, ok := err.(*APIError); ok {
+			apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+			if errors.Is(err, ErrAuthFailed) {
+				apiErr.ClockSkew = c.ClockSkew() - c.correction()
+			}
+		}
+		c.log(ctx, slog.LevelError, "cactus call failed", append(attrs, c.errorAttr(err))...)
+		return nil, err
 	}
+	c.log(ctx, slog.LevelDebug, "cactus call", attrs...)
+	c.log(ctx, slog.LevelDebug, "cactus response", append(attrs, slog.String("body", redactBody(all, redaction)))...)
+	return all, nil
 }
@@ -0,0 +1,122 @@
+package cactus
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/utils"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestClient(t testing.TB, handler http.Handler) *Cactus {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewCactus(server.URL, "test-api-key", "test-key-id", key, server.Client(), -1)
+}
+
+func TestContextCancelsRequest(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.GetCoinInfoWithContext(ctx, "BTC", "")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetCoinInfoWithContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestGetQueryEncoding(t *testing.T) {
+	var query url.Values
+	var sent bool
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, sent = r.URL.Query(), r.URL.RawQuery != "" || strings.HasSuffix(r.RequestURI, "?")
+		w.Write([]byte(`{"code":0,"successful":true,"data":{}}`))
+	}))
+	if _, err := client.GetOrderDetails("b", "o"); err != nil {
+		t.Fatalf("GetOrderDetails() error = %v", err)
+	}
+	if sent {
+		t.Errorf("GetOrderDetails() sent query %v, want none", query)
+	}
+	_, err := client.GetWalletList("b", "", false, []constants.CactusToken{"BTC", "LTC"}, "", "a b&c", "", "", "",
+		constants.OrderTypeNotUsed, constants.OrderTypeNotUsed, 0, 0)
+	if err != nil {
+		t.Fatalf("GetWalletList() error = %v", err)
+	}
+	if got := query.Get("coin_names"); got != "BTC,LTC" {
+		t.Errorf("coin_names = %q, want %q", got, "BTC,LTC")
+	}
+	if got := query.Get("keyword"); got != "a b&c" {
+		t.Errorf("keyword = %q, want %q", got, "a b&c")
+	}
+}
+
+func TestDo(t *testing.T) {
+	type widget struct {
+		Name  string   `json:"name"`
+		Coins []string `json:"coins"`
+	}
+	var client *Cactus
+	client = newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifier := utils.NewVerifier(func(string) (*ecdsa.PublicKey, error) { return &client.PrivateKey.PublicKey, nil }, time.Minute)
+		if _, err := verifier.VerifyAuthorizationHeader(r); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintf(w, `{"code":401,"message":%q,"successful":false}`, err.Error())
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, `{"code":200,"successful":true,"data":{"method":%q,"query":%q,"body":%q}}`, r.Method, r.URL.RawQuery, body)
+	}))
+	var out struct {
+		Code int `json:"code"`
+		Data struct {
+			Method string `json:"method"`
+			Query  string `json:"query"`
+			Body   string `json:"body"`
+		} `json:"data"`
+	}
+	methods := []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}
+	for _, method := range methods {
+		var body any
+		if method != http.MethodGet {
+			body = widget{Name: "a b", Coins: []string{"BTC"}}
+		}
+		err := client.Do(context.Background(), method, "/custody/v1/api/widgets", url.Values{"keyword": {"x&y"}}, body, &out)
+		if err != nil {
+			t.Fatalf("Do(%s) error = %v", method, err)
+		}
+		if out.Data.Method != method || out.Data.Query != "keyword=x%26y" {
+			t.Errorf("Do(%s) sent %+v", method, out.Data)
+		}
+		if method != http.MethodGet && out.Data.Body != `{"name":"a b","coins":["BTC"]}` {
+			t.Errorf("Do(%s) sent body %s", method, out.Data.Body)
+		}
+	}
+	if err := client.Do(context.Background(), http.MethodPatch, "/custody/v1/api/widgets", nil, nil, nil); err == nil {
+		t.Error("Do(PATCH) succeeded, want an error")
+	}
+	if err := client.Do(context.Background(), http.MethodGet, "/custody/v1/api/widgets", nil, widget{}, nil); err == nil {
+		t.Error("Do(GET) with a body succeeded, want an error")
+	}
+}
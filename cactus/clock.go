@@ -0,0 +1,67 @@
+package cactus
+
+import (
+	"context"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/utils"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// SkewWarnThreshold is the clock skew above which the client warns and auth errors carry a skew hint
+const SkewWarnThreshold = 5 * time.Second
+
+// minSkewCorrection ignores offsets within the one second resolution of the Date header
+const minSkewCorrection = time.Second
+
+func (c *Cactus) clock() time.Time {
+	if c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now()
+}
+
+// now is the time requests are signed with, the local clock corrected by the calibrated skew
+func (c *Cactus) now() time.Time {
+	return c.clock().Add(c.correction())
+}
+
+func (c *Cactus) nonce() string {
+	if c.NonceSource != nil {
+		return c.NonceSource()
+	}
+	return utils.GenerateUuid()
+}
+
+// ClockSkew returns how far the cactus clock was ahead of the local clock on the last response,
+// negative if the local clock is ahead, 0 before the first response carrying a Date header
+func (c *Cactus) ClockSkew() time.Duration {
+	return time.Duration(c.skew.Load())
+}
+
+// correction is the offset added to the local clock, only when CalibrateSkew is on
+func (c *Cactus) correction() time.Duration {
+	skew := c.ClockSkew()
+	if !c.CalibrateSkew || (skew > -minSkewCorrection && skew < minSkewCorrection) {
+		return 0
+	}
+	return skew
+}
+
+// observeDate measures the skew from the Date header of a response
+// sent and received are local clock readings, the server is assumed to stamp the response half way.
+func (c *Cactus) observeDate(ctx context.Context, header http.Header, sent time.Time, received time.Time) {
+	serverTime, err := http.ParseTime(header.Get("Date"))
+	if err != nil {
+		return
+	}
+	skew := serverTime.Sub(sent.Add(received.Sub(sent) / 2)).Round(time.Second)
+	if previous := time.Duration(c.skew.Swap(int64(skew))); previous == skew {
+		return
+	}
+	level := slog.LevelDebug
+	if !c.CalibrateSkew && (skew >= SkewWarnThreshold || skew <= -SkewWarnThreshold) {
+		level = slog.LevelWarn
+	}
+	c.log(ctx, level, "cactus clock skew changed", slog.Duration("skew", skew), slog.Bool("calibrated", c.CalibrateSkew))
+}
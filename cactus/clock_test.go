@@ -0,0 +1,78 @@
+package cactus
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/utils"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeterministicClockAndNonce(t *testing.T) {
+	fixed := time.Date(2020, 3, 3, 12, 26, 57, 0, time.UTC)
+	var client *Cactus
+	var keyID string
+	var verifyErr error
+	var date, nonce string
+	client = newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date, nonce = r.Header.Get("Date"), r.Header.Get("x-api-nonce")
+		verifier := utils.NewVerifier(func(string) (*ecdsa.PublicKey, error) { return &client.PrivateKey.PublicKey, nil }, time.Minute)
+		verifier.Now = func() time.Time { return fixed }
+		keyID, verifyErr = verifier.VerifyAuthorizationHeader(r)
+		w.Write([]byte(`{"code":0,"successful":true,"data":[]}`))
+	}))
+	client.Clock = func() time.Time { return fixed }
+	client.NonceSource = func() string { return "36dbe33ed529455cb0638eef0f5f59e3" }
+	if _, err := client.GetChainInfo("ETH", ""); err != nil {
+		t.Fatalf("GetChainInfo() error = %v", err)
+	}
+	if date != "Tue, 03 Mar 2020 12:26:57 GMT" || nonce != "36dbe33ed529455cb0638eef0f5f59e3" {
+		t.Errorf("request dated %q with nonce %q", date, nonce)
+	}
+	if verifyErr != nil || keyID != "test-key-id" {
+		t.Errorf("VerifyAuthorizationHeader() = %q, %v", keyID, verifyErr)
+	}
+}
+
+func TestCalibrateSkew(t *testing.T) {
+	var date string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date = r.Header.Get("Date")
+		w.Header().Set("Date", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		w.Write([]byte(`{"code":0,"successful":true,"data":[]}`))
+	}))
+	client.CalibrateSkew = true
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetChainInfo("ETH", ""); err != nil {
+			t.Fatalf("GetChainInfo() error = %v", err)
+		}
+	}
+	if skew := client.ClockSkew(); skew < time.Hour-2*time.Second || skew > time.Hour+2*time.Second {
+		t.Errorf("ClockSkew() = %s, want about 1h", skew)
+	}
+	sent, err := http.ParseTime(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset := sent.Sub(time.Now()); offset < time.Hour-3*time.Second || offset > time.Hour+time.Second {
+		t.Errorf("calibrated request dated %s from now, want about 1h", offset)
+	}
+}
+
+func TestSkewHintOnAuthFailure(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-10*time.Minute).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"code":401,"message":"signature verification failed","successful":false}`))
+	}))
+	_, err := client.GetChainInfo("ETH", "")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("GetChainInfo() error = %v, want an auth failure", err)
+	}
+	if apiErr.ClockSkew > -9*time.Minute || !strings.Contains(err.Error(), "CalibrateSkew") {
+		t.Errorf("error %q with ClockSkew %s, want a hint about a 10m skew", err, apiErr.ClockSkew)
+	}
+}
@@ -1,9 +1,11 @@
 package cactus
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"net/url"
 	"strconv"
 )
 
@@ -14,55 +16,70 @@ const (
 	GetDefiTransactionDetailsUrl = "/custody/v1/api/projects/%s/wallets/%s/contract/orders/%s"
 )
 
+// CreateContractOrderReq is the body of a contract call, the gas prices are in wei and left out when zero
 type CreateContractOrderReq struct {
 	OrderNo              string              `json:"order_no"`
 	FromWalletCode       string              `json:"from_wallet_code"`
 	FromAddress          string              `json:"from_address"`
 	ToAddress            string              `json:"to_address"`
-	Amount               string              `json:"amount"`
+	Amount               Amount              `json:"amount"`
 	Chain                constants.ChainName `json:"chain"`
 	ContractData         string              `json:"contract_data"`
 	GasPriceLevel        string              `json:"gas_price_level,omitempty"`
-	GasPrice             int64               `json:"gas_price,omitempty"`
-	MaxFeePerGas         int64               `json:"max_fee_per_gas,omitempty"`
-	MaxPriorityFeePerGas int64               `json:"max_priority_fee_per_gas,omitempty"`
+	GasPrice             Amount              `json:"gas_price"`
+	MaxFeePerGas         Amount              `json:"max_fee_per_gas"`
+	MaxPriorityFeePerGas Amount              `json:"max_priority_fee_per_gas"`
 	GasLimit             int                 `json:"gas_limit"`
 	Description          string              `json:"description"`
 }
 
-type CreateContractOrderResp struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Data    struct {
-		OrderNo string `json:"OrderNo"`
-	} `json:"data"`
-	Successful bool `json:"successful"`
+// MarshalJSON sends amount as a decimal string, the format the contract call endpoint takes
+func (r CreateContractOrderReq) MarshalJSON() ([]byte, error) {
+	type plain CreateContractOrderReq
+	return json.Marshal(struct {
+		plain
+		Amount               string  `json:"amount"`
+		GasPrice             *Amount `json:"gas_price,omitempty"`
+		MaxFeePerGas         *Amount `json:"max_fee_per_gas,omitempty"`
+		MaxPriorityFeePerGas *Amount `json:"max_priority_fee_per_gas,omitempty"`
+	}{
+		plain:                plain(r),
+		Amount:               r.Amount.String(),
+		GasPrice:             nonZero(r.GasPrice),
+		MaxFeePerGas:         nonZero(r.MaxFeePerGas),
+		MaxPriorityFeePerGas: nonZero(r.MaxPriorityFeePerGas),
+	})
 }
 
+// nonZero returns nil for a zero amount, for optional fields
+func nonZero(a Amount) *Amount {
+	if a.IsZero() {
+		return nil
+	}
+	return &a
+}
+
+type CreateContractOrderResp = Response[CreatedOrder]
+
 // CreateContractOrder creates a contract order
 // bId: business id
-// req: request body
+// req: request body, set OrderNo to let the client retry safely under Retry
 func (c *Cactus) CreateContractOrder(bId string, req CreateContractOrderReq) (*CreateContractOrderResp, error) {
+	return c.CreateContractOrderWithContext(context.Background(), bId, req)
+}
+
+// CreateContractOrderWithContext is CreateContractOrder with a caller supplied context.
+func (c *Cactus) CreateContractOrderWithContext(ctx context.Context, bId string, req CreateContractOrderReq) (*CreateContractOrderResp, error) {
 	path := fmt.Sprintf(CreateContractOrderUrl, bId)
-	var reqMap map[string]interface{}
-	reqBytes, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(reqBytes, &reqMap)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.post(path, reqMap)
-	if err != nil {
-		return nil, err
-	}
-	var createContractOrderResp CreateContractOrderResp
-	err = json.Unmarshal(resp, &createContractOrderResp)
-	if err != nil {
-		return nil, err
-	}
-	return &createContractOrderResp, nil
+	// Only resent when order_no is set and the order does not exist yet, contract orders are not
+	// listed with the withdrawal orders so they are looked up in the wallet
+	return c.postOrder(ctx, path, req.OrderNo, req, func(ctx context.Context, orderNo string) (*Response[CreatedOrder], error) {
+		resp, err := c.GetDefiTransactionDetailsWithContext(ctx, bId, req.FromWalletCode, orderNo)
+		if err != nil {
+			return nil, err
+		}
+		return createdOrder(resp, orderNo), nil
+	})
 }
 
 type CreateSignOrderReq struct {
@@ -74,27 +91,16 @@ type CreateSignOrderReq struct {
 	Description      string                     `json:"description,omitempty"`
 }
 
-type CreateSignOrderResp struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	Successful bool   `json:"successful"`
-	Data       struct {
-		OrderNo string `json:"OrderNo"`
-	} `json:"data"`
-}
+type CreateSignOrderResp = Response[CreatedOrder]
 
 func (c *Cactus) CreateSignOrder(bId string, walletCode string, req CreateSignOrderReq) (*CreateSignOrderResp, error) {
+	return c.CreateSignOrderWithContext(context.Background(), bId, walletCode, req)
+}
+
+// CreateSignOrderWithContext is CreateSignOrder with a caller supplied context.
+func (c *Cactus) CreateSignOrderWithContext(ctx context.Context, bId string, walletCode string, req CreateSignOrderReq) (*CreateSignOrderResp, error) {
 	path := fmt.Sprintf(CreateSignOrderUrl, bId, walletCode)
-	var reqMap map[string]interface{}
-	reqBytes, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(reqBytes, &reqMap)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.post(path, reqMap)
+	resp, err := c.post(ctx, path, req)
 	if err != nil {
 		return nil, err
 	}
@@ -106,38 +112,7 @@ func (c *Cactus) CreateSignOrder(bId string, walletCode string, req CreateSignOr
 	return &createSignOrderResp, nil
 }
 
-type GetTransactionHistoryResp struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Data    struct {
-		Offset int `json:"offset"`
-		Limit  int `json:"limit"`
-		List   []struct {
-			TimeStamp       int64       `json:"time_stamp"`
-			MinerFee        int64       `json:"miner_fee"`
-			OrderNo         string      `json:"order_no"`
-			ContractAddress string      `json:"contract_address"`
-			GasPrice        int64       `json:"gas_price"`
-			GasLimit        int         `json:"gas_limit"`
-			ContractData    string      `json:"contract_data"`
-			Applicant       string      `json:"applicant"`
-			Status          string      `json:"status"`
-			Amount          int64       `json:"amount"`
-			Description     interface{} `json:"description"`
-			TxId            string      `json:"tx_id"`
-			DepositTrans    []struct {
-				Amount   int64  `json:"amount"`
-				CoinName string `json:"coin_name"`
-			} `json:"deposit_trans"`
-			WithdrawTrans []struct {
-				Amount   int64  `json:"amount"`
-				CoinName string `json:"coin_name"`
-			} `json:"withdraw_trans"`
-		} `json:"list"`
-		Total int `json:"total"`
-	} `json:"data"`
-	Successful bool `json:"successful"`
-}
+type GetTransactionHistoryResp = Response[Page[ContractOrder]]
 
 // GetTransactionHistory gets the transaction history
 // bId: business id
@@ -150,29 +125,34 @@ type GetTransactionHistoryResp struct {
 // limit: limit, optional
 // offset: offset, optional
 func (c *Cactus) GetTransactionHistory(bId string, walletCode string, keyword string, sortByTime string, status string, chain constants.ChainName, startTime int64, limit int, offset int) (*GetTransactionHistoryResp, error) {
-	query := map[string]string{
-		"chain": string(chain),
+	return c.GetTransactionHistoryWithContext(context.Background(), bId, walletCode, keyword, sortByTime, status, chain, startTime, limit, offset)
+}
+
+// GetTransactionHistoryWithContext is GetTransactionHistory with a caller supplied context.
+func (c *Cactus) GetTransactionHistoryWithContext(ctx context.Context, bId string, walletCode string, keyword string, sortByTime string, status string, chain constants.ChainName, startTime int64, limit int, offset int) (*GetTransactionHistoryResp, error) {
+	query := url.Values{
+		"chain": {string(chain)},
 	}
 	if keyword != "" {
-		query["keyword"] = keyword
+		query.Set("keyword", keyword)
 	}
 	if sortByTime != "" {
-		query["sort_by_time"] = sortByTime
+		query.Set("sort_by_time", sortByTime)
 	}
 	if status != "" {
-		query["status"] = status
+		query.Set("status", status)
 	}
 	if startTime != 0 {
-		query["start_time"] = strconv.FormatInt(startTime, 10)
+		query.Set("start_time", strconv.FormatInt(startTime, 10))
 	}
 	if limit != 0 {
-		query["limit"] = strconv.Itoa(limit)
+		query.Set("limit", strconv.Itoa(limit))
 	}
 	if offset != 0 {
-		query["offset"] = strconv.Itoa(offset)
+		query.Set("offset", strconv.Itoa(offset))
 	}
-	url := fmt.Sprintf(GetDefiTransactionHistoryUrl, bId, walletCode)
-	resp, err := c.get(url, query)
+	path := fmt.Sprintf(GetDefiTransactionHistoryUrl, bId, walletCode)
+	resp, err := c.get(ctx, path, query)
 	if err != nil {
 		return nil, err
 	}
@@ -184,35 +164,16 @@ func (c *Cactus) GetTransactionHistory(bId string, walletCode string, keyword st
 	return &getTransactionHistoryResp, nil
 }
 
-type GetDefiTransactionDetailsResp struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Data    struct {
-		TimeStamp        int64       `json:"time_stamp"`
-		MinerFee         int64       `json:"miner_fee"`
-		OrderNo          string      `json:"order_no"`
-		ContractAddress  string      `json:"contract_address"`
-		ContractFunction string      `json:"contract_function"`
-		GasPrice         int64       `json:"gas_price"`
-		GasLimit         int         `json:"gas_limit"`
-		ContractData     string      `json:"contract_data"`
-		Applicant        string      `json:"applicant"`
-		Status           string      `json:"status"`
-		Amount           int         `json:"amount"`
-		TxId             string      `json:"tx_id"`
-		Description      interface{} `json:"description"`
-		DepositTrans     []struct {
-			Amount   int64  `json:"amount"`
-			CoinName string `json:"coin_name"`
-		} `json:"deposit_trans"`
-		WithdrawTrans []interface{} `json:"withdraw_trans"`
-	} `json:"data"`
-	Successful bool `json:"successful"`
-}
+type GetDefiTransactionDetailsResp = Response[ContractOrder]
 
 func (c *Cactus) GetDefiTransactionDetails(bId string, walletCode string, orderNo string) (*GetDefiTransactionDetailsResp, error) {
+	return c.GetDefiTransactionDetailsWithContext(context.Background(), bId, walletCode, orderNo)
+}
+
+// GetDefiTransactionDetailsWithContext is GetDefiTransactionDetails with a caller supplied context.
+func (c *Cactus) GetDefiTransactionDetailsWithContext(ctx context.Context, bId string, walletCode string, orderNo string) (*GetDefiTransactionDetailsResp, error) {
 	url := fmt.Sprintf(GetDefiTransactionDetailsUrl, bId, walletCode, orderNo)
-	resp, err := c.get(url, nil)
+	resp, err := c.get(ctx, url, nil)
 	if err != nil {
 		return nil, err
 	}
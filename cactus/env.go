@@ -0,0 +1,93 @@
+package cactus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/keys"
+	"os"
+	"path/filepath"
+)
+
+// Environment variables read by package config, and by the deprecated NewCactusFromEnv
+const (
+	EnvBaseUri              = "CACTUS_BASE_URI"
+	EnvApiKey               = "CACTUS_API_KEY"
+	EnvApiKeyID             = "CACTUS_API_KEY_ID"
+	EnvPrivateKeyFile       = "CACTUS_PRIVATE_KEY_FILE"
+	EnvPrivateKeyPassphrase = "CACTUS_PRIVATE_KEY_PASSPHRASE"
+)
+
+// FileConfig is the json file read by NewCactusFromFile
+//
+// Deprecated: use a profile file of package config.
+type FileConfig struct {
+	BaseUri  string `json:"base_uri"`
+	ApiKey   string `json:"api_key"`
+	ApiKeyID string `json:"api_key_id"`
+	// PrivateKeyFile is resolved relative to the config file
+	PrivateKeyFile string `json:"private_key_file"`
+	// PrivateKeyPassphrase is only needed for encrypted keys, CACTUS_PRIVATE_KEY_PASSPHRASE overrides it
+	PrivateKeyPassphrase string `json:"private_key_passphrase,omitempty"`
+}
+
+// NewCactusFromEnv creates a client from the CACTUS_* environment variables
+// CACTUS_BASE_URI, CACTUS_API_KEY, CACTUS_API_KEY_ID and CACTUS_PRIVATE_KEY_FILE are required,
+// CACTUS_PRIVATE_KEY_PASSPHRASE is needed for encrypted keys.
+//
+// Deprecated: use config.FromEnv, or config.LoadProfile to also honor $CACTUS_CONFIG, and
+// Profile.NewClient. They validate the base uri against the environment of the key.
+func NewCactusFromEnv() (*Cactus, error) {
+	return newCactusFromConfig(FileConfig{
+		BaseUri:              os.Getenv(EnvBaseUri),
+		ApiKey:               os.Getenv(EnvApiKey),
+		ApiKeyID:             os.Getenv(EnvApiKeyID),
+		PrivateKeyFile:       os.Getenv(EnvPrivateKeyFile),
+		PrivateKeyPassphrase: os.Getenv(EnvPrivateKeyPassphrase),
+	})
+}
+
+// NewCactusFromFile creates a client from a json FileConfig
+//
+// Deprecated: use config.LoadProfile and Profile.NewClient.
+func NewCactusFromFile(path string) (*Cactus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config FileConfig
+	if err = json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if config.PrivateKeyFile != "" && !filepath.IsAbs(config.PrivateKeyFile) {
+		config.PrivateKeyFile = filepath.Join(filepath.Dir(path), config.PrivateKeyFile)
+	}
+	if passphrase := os.Getenv(EnvPrivateKeyPassphrase); passphrase != "" {
+		config.PrivateKeyPassphrase = passphrase
+	}
+	return newCactusFromConfig(config)
+}
+
+func newCactusFromConfig(config FileConfig) (*Cactus, error) {
+	var missing []error
+	if config.BaseUri == "" {
+		missing = append(missing, errors.New("base uri is not set"))
+	}
+	if config.ApiKey == "" {
+		missing = append(missing, errors.New("api key is not set"))
+	}
+	if config.ApiKeyID == "" {
+		missing = append(missing, errors.New("api key id is not set"))
+	}
+	if config.PrivateKeyFile == "" {
+		missing = append(missing, errors.New("private key file is not set"))
+	}
+	if len(missing) > 0 {
+		return nil, errors.Join(missing...)
+	}
+	key, err := keys.LoadPrivateKeyFile(config.PrivateKeyFile, []byte(config.PrivateKeyPassphrase))
+	if err != nil {
+		return nil, err
+	}
+	return NewCactus(config.BaseUri, config.ApiKey, config.ApiKeyID, key, nil, 0), nil
+}
@@ -0,0 +1,137 @@
+package cactus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for the common failure cases, use errors.Is to match them against an *APIError
+var (
+	ErrAuthFailed          = errors.New("cactus: authentication failed")
+	ErrInsufficientBalance = errors.New("cactus: insufficient balance")
+	ErrInvalidAddress      = errors.New("cactus: invalid address")
+	ErrOrderNotFound       = errors.New("cactus: order not found")
+	ErrRateLimited         = errors.New("cactus: rate limited")
+)
+
+// maxErrorBodyLen limits how much of a non json body is kept in APIError.Message
+const maxErrorBodyLen = 256
+
+// APIError is returned when cactus answers with a non 2xx status, a body that is not a cactus
+// response, or a response with successful set to false
+type APIError struct {
+	StatusCode int           // http status code
+	Code       int           // cactus code, 0 if the body did not carry one
+	Message    string        // cactus message, or a description of the unexpected body
+	Nonce      string        // x-api-nonce of the failed request
+	Path       string        // request path, without query
+	RetryAfter time.Duration // value of the Retry-After header, 0 if absent
+	ClockSkew  time.Duration // on auth failures, how far the request Date was behind the cactus clock
+	kind       error
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("cactus: %s (http %d, code %d, path %s, nonce %s)", e.Message, e.StatusCode, e.Code, e.Path, e.Nonce)
+	if e.ClockSkew >= SkewWarnThreshold || e.ClockSkew <= -SkewWarnThreshold {
+		// A skewed Date is the usual reason for a correctly signed request to be rejected
+		msg += fmt.Sprintf(", request date was off by %s, check the local clock or set Cactus.CalibrateSkew", e.ClockSkew)
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error matching this failure, if any
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+type responseEnvelope struct {
+	Code       int         `json:"code"`
+	Message    string      `json:"message"`
+	Successful interface{} `json:"successful"`
+}
+
+// checkResponse turns a failed cactus response into an *APIError, returns nil for successful ones
+// The cactus code is returned in both cases.
+func checkResponse(statusCode int, body []byte, nonce string, path string) (int, error) {
+	var envelope responseEnvelope
+	decodeErr := json.Unmarshal(body, &envelope)
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Code:       envelope.Code,
+		Message:    envelope.Message,
+		Nonce:      nonce,
+		Path:       path,
+	}
+	switch {
+	case decodeErr != nil:
+		apiErr.Message = "unexpected response body: " + truncateBody(body)
+	case statusCode < 200 || statusCode > 299:
+	case envelope.Successful == false:
+	case envelope.Successful == nil && envelope.Code != 0 && envelope.Code != http.StatusOK:
+	default:
+		return envelope.Code, nil
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(statusCode)
+	}
+	apiErr.kind = classifyError(apiErr)
+	return envelope.Code, apiErr
+}
+
+// classifyError maps a failed response to one of the sentinel errors
+// The http status decides first, then the cactus code, which mirrors http statuses. Only when neither
+// is conclusive is the message searched, and a 400 request error is never taken for an auth failure,
+// as a malformed sign string or Content-SHA256 is reported with words like "signature".
+func classifyError(e *APIError) error {
+	if kind := classifyStatus(e.StatusCode, e.Path); kind != nil {
+		return kind
+	}
+	if kind := classifyStatus(e.Code, e.Path); kind != nil {
+		return kind
+	}
+	badRequest := e.StatusCode == http.StatusBadRequest || e.Code == http.StatusBadRequest
+	message := strings.ToLower(e.Message)
+	switch {
+	case strings.Contains(message, "insufficient"):
+		return ErrInsufficientBalance
+	case strings.Contains(message, "address") && (strings.Contains(message, "invalid") || strings.Contains(message, "illegal")):
+		return ErrInvalidAddress
+	case strings.Contains(message, "order") && (strings.Contains(message, "not found") || strings.Contains(message, "not exist")):
+		return ErrOrderNotFound
+	case badRequest:
+		return nil
+	case strings.Contains(message, "too many requests"), strings.Contains(message, "rate limit"):
+		return ErrRateLimited
+	case strings.Contains(message, "unauthorized"), strings.Contains(message, "authentication failed"),
+		strings.Contains(message, "invalid api key"), strings.Contains(message, "invalid api-key"):
+		return ErrAuthFailed
+	}
+	return nil
+}
+
+// classifyStatus maps an http status, or a cactus code mirroring one, to a sentinel error
+func classifyStatus(status int, path string) error {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAuthFailed
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusNotFound:
+		if strings.Contains(path, "/orders/") {
+			return ErrOrderNotFound
+		}
+	}
+	return nil
+}
+
+func truncateBody(body []byte) string {
+	s := strings.TrimSpace(string(body))
+	if len(s) > maxErrorBodyLen {
+		return s[:maxErrorBodyLen] + "..."
+	}
+	return s
+}
@@ -0,0 +1,81 @@
+package cactus
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		path       string
+		wantErr    bool
+		wantKind   error
+	}{
+		{"success", 200, `{"code":200,"message":"success","successful":true}`, "/p", false, nil},
+		{"success without flag", 200, `{"code":200,"message":"success","data":{}}`, "/p", false, nil},
+		{"unsuccessful", 200, `{"code":10001,"message":"Insufficient balance","successful":false}`, "/p", true, ErrInsufficientBalance},
+		{"unauthorized", 401, `{"code":401,"message":"denied","successful":false}`, "/p", true, ErrAuthFailed},
+		{"rate limited html", 429, `<html>slow down</html>`, "/p", true, ErrRateLimited},
+		{"html page", 502, `<html>bad gateway</html>`, "/p", true, nil},
+		{"order not found", 404, `{"code":404,"message":"not found","successful":false}`, "/custody/v1/api/projects/b/orders/o", true, ErrOrderNotFound},
+		{"invalid address", 200, `{"code":1,"message":"Invalid address format","successful":false}`, "/p", true, ErrInvalidAddress},
+		{"auth code", 200, `{"code":401,"message":"denied","successful":false}`, "/p", true, ErrAuthFailed},
+		{"rate limited code", 200, `{"code":429,"message":"slow down","successful":false}`, "/p", true, ErrRateLimited},
+		{"order not found code", 200, `{"code":404,"message":"missing","successful":false}`, "/custody/v1/api/projects/b/orders/o", true, ErrOrderNotFound},
+		{"bad sign string", 400, `{"code":400,"message":"signature string is malformed","successful":false}`, "/p", true, nil},
+		{"missing content hash", 200, `{"code":400,"message":"Content-SHA256 is required for signature","successful":false}`, "/p", true, nil},
+		{"bad request unauthorized message", 400, `{"code":400,"message":"unauthorized field","successful":false}`, "/p", true, nil},
+		{"message illegal address", 400, `{"code":400,"message":"illegal address","successful":false}`, "/p", true, ErrInvalidAddress},
+		{"message order not exist", 200, `{"code":1,"message":"order not exist","successful":false}`, "/p", true, ErrOrderNotFound},
+		{"message order not found", 200, `{"code":1,"message":"Order not found","successful":false}`, "/p", true, ErrOrderNotFound},
+		{"message too many requests", 200, `{"code":1,"message":"Too many requests","successful":false}`, "/p", true, ErrRateLimited},
+		{"message rate limit", 200, `{"code":1,"message":"rate limit exceeded","successful":false}`, "/p", true, ErrRateLimited},
+		{"message unauthorized", 200, `{"code":1,"message":"Unauthorized","successful":false}`, "/p", true, ErrAuthFailed},
+		{"message authentication failed", 200, `{"code":1,"message":"authentication failed","successful":false}`, "/p", true, ErrAuthFailed},
+		{"message invalid api key", 200, `{"code":1,"message":"invalid api key","successful":false}`, "/p", true, ErrAuthFailed},
+		{"message invalid api-key", 200, `{"code":1,"message":"Invalid api-key","successful":false}`, "/p", true, ErrAuthFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkResponse(tt.statusCode, []byte(tt.body), "nonce", tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkResponse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("checkResponse() error = %T, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.statusCode || apiErr.Nonce != "nonce" || apiErr.Path != tt.path {
+				t.Errorf("checkResponse() = %+v", apiErr)
+			}
+			if tt.wantKind != nil && !errors.Is(err, tt.wantKind) {
+				t.Errorf("checkResponse() error = %v, want %v", err, tt.wantKind)
+			}
+			if tt.wantKind == nil && errors.Unwrap(err) != nil {
+				t.Errorf("checkResponse() error = %v, want no sentinel", err)
+			}
+		})
+	}
+}
+
+func TestFailedCallReturnsAPIError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"code":403,"message":"invalid signature","successful":false}`))
+	}))
+	resp, err := client.GetCoinInfo("BTC", "")
+	if resp != nil || !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("GetCoinInfo() = %v, %v, want nil, %v", resp, err, ErrAuthFailed)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Nonce == "" || apiErr.Path != GetCoinInfoUrl {
+		t.Errorf("GetCoinInfo() error = %+v", apiErr)
+	}
+}
@@ -0,0 +1,203 @@
+package cactus
+
+import (
+	"errors"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidTimeRange is returned for filters whose End is before their Start
+var ErrInvalidTimeRange = errors.New("cactus: filter end is before start")
+
+// SortOrder is the sort order of a list, the zero value leaves it to cactus
+type SortOrder int
+
+const (
+	SortUnset SortOrder = iota
+	SortDesc
+	SortAsc
+)
+
+// sortOrderOf maps the positional list arguments onto SortOrder
+func sortOrderOf(o constants.OrderType) SortOrder {
+	switch o {
+	case constants.OrderTypeDesc:
+		return SortDesc
+	case constants.OrderTypeAsc:
+		return SortAsc
+	}
+	return SortUnset
+}
+
+func (o SortOrder) set(params url.Values, key string) {
+	switch o {
+	case SortDesc:
+		params.Set(key, strconv.Itoa(int(constants.OrderTypeDesc)))
+	case SortAsc:
+		params.Set(key, strconv.Itoa(int(constants.OrderTypeAsc)))
+	}
+}
+
+// timeOf maps a positional millisecond bound onto time.Time, 0 is unset
+func timeOf(ms int64) time.Time {
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
+
+// setTimeRange sets start_time and end_time in milliseconds, zero times are not sent
+func setTimeRange(params url.Values, start time.Time, end time.Time) error {
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return ErrInvalidTimeRange
+	}
+	if !start.IsZero() {
+		params.Set("start_time", strconv.FormatInt(start.UnixMilli(), 10))
+	}
+	if !end.IsZero() {
+		params.Set("end_time", strconv.FormatInt(end.UnixMilli(), 10))
+	}
+	return nil
+}
+
+func setPage(params url.Values, offset int, limit int) {
+	if offset != 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+	if limit != 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+}
+
+// WalletFilter selects the wallets returned by ListWallets, zero fields are not sent
+type WalletFilter struct {
+	BId              string
+	Type             constants.WalletFilterType
+	HideNoCoinWallet bool
+	CoinNames        []constants.CactusToken
+	WalletType       constants.WalletType
+	Keyword          string
+	DefiWalletCode   string
+	MainWalletCode   string
+	Chain            constants.ChainName
+	TotalMarketOrder SortOrder
+	CreateTimeOrder  SortOrder
+}
+
+func (f WalletFilter) values() url.Values {
+	params := url.Values{}
+	if f.BId != "" {
+		params.Set("b_id", f.BId)
+	}
+	if f.Type != "" {
+		params.Set("type", string(f.Type))
+	}
+	if f.HideNoCoinWallet {
+		params.Set("hide_no_coin_wallet", "true")
+	}
+	for _, coinName := range f.CoinNames {
+		params.Add("coin_names", string(coinName))
+	}
+	if f.WalletType != "" {
+		params.Set("wallet_types", string(f.WalletType))
+	}
+	if f.Keyword != "" {
+		params.Set("keyword", f.Keyword)
+	}
+	if f.DefiWalletCode != "" {
+		params.Set("defi_wallet_code", f.DefiWalletCode)
+	}
+	if f.MainWalletCode != "" {
+		params.Set("main_wallet_code", f.MainWalletCode)
+	}
+	if f.Chain != "" {
+		params.Set("chain", string(f.Chain))
+	}
+	f.TotalMarketOrder.set(params, "total_market_order")
+	f.CreateTimeOrder.set(params, "create_time_order")
+	return params
+}
+
+// OrderFilter selects the orders returned by ListOrders, zero fields are not sent
+// Start and End bound the order creation time.
+type OrderFilter struct {
+	Applicants  []string
+	CoinNames   []constants.CactusToken
+	Chains      []constants.ChainName
+	WalletNames []string
+	Statuses    []string
+	Keyword     string
+	SortByTime  SortOrder
+	Start       time.Time
+	End         time.Time
+}
+
+func (f OrderFilter) values() (url.Values, error) {
+	params := url.Values{}
+	if f.Keyword != "" {
+		params.Set("keyword", f.Keyword)
+	}
+	if err := setTimeRange(params, f.Start, f.End); err != nil {
+		return nil, err
+	}
+	f.SortByTime.set(params, "sort_by_time")
+	for _, app := range f.Applicants {
+		params.Add("applicant", app)
+	}
+	for _, coin := range f.CoinNames {
+		params.Add("coin_name", string(coin))
+	}
+	for _, chain := range f.Chains {
+		params.Add("chain_name", string(chain))
+	}
+	for _, wallet := range f.WalletNames {
+		params.Add("wallet_name", wallet)
+	}
+	for _, stat := range f.Statuses {
+		params.Add("status", stat)
+	}
+	return params, nil
+}
+
+// TxDetailFilter selects the transactions returned by ListTransactionDetails, zero fields are not sent
+// Start and End bound the transaction time.
+type TxDetailFilter struct {
+	CoinName        constants.CactusToken
+	TxTypes         []constants.TxType
+	Addresses       []string
+	Id              string
+	TxId            string
+	OrderNo         string
+	CreateTimeOrder SortOrder
+	Start           time.Time
+	End             time.Time
+}
+
+func (f TxDetailFilter) values() (url.Values, error) {
+	params := url.Values{}
+	if f.CoinName != "" {
+		params.Set("coin_name", string(f.CoinName))
+	}
+	f.CreateTimeOrder.set(params, "create_time_order")
+	if err := setTimeRange(params, f.Start, f.End); err != nil {
+		return nil, err
+	}
+	for _, txType := range f.TxTypes {
+		params.Add("tx_types", string(txType))
+	}
+	for _, address := range f.Addresses {
+		params.Add("addresses", address)
+	}
+	if f.Id != "" {
+		params.Set("id", f.Id)
+	}
+	if f.TxId != "" {
+		params.Set("tx_id", f.TxId)
+	}
+	if f.OrderNo != "" {
+		params.Set("order_no", f.OrderNo)
+	}
+	return params, nil
+}
@@ -0,0 +1,52 @@
+package cactus
+
+import (
+	"errors"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestFilterQueries(t *testing.T) {
+	var queries []url.Values
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.Write([]byte(`{"code":200,"successful":true,"data":{"list":[]}}`))
+	}))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	if _, err := client.ListOrders("b", OrderFilter{Statuses: []string{"COMPLETED"}, SortByTime: SortDesc, Start: start, End: end}, 0, 20); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetFilteredOrder("b", nil, nil, nil, nil, []string{"COMPLETED"}, "", constants.OrderTypeDesc, int(start.UnixMilli()), int(end.UnixMilli()), 0, 20); err != nil {
+		t.Fatal(err)
+	}
+	want := url.Values{"status": {"COMPLETED"}, "sort_by_time": {"0"}, "start_time": {"1704067200000"}, "end_time": {"1704153600000"}, "limit": {"20"}}
+	for i, q := range queries {
+		if q.Encode() != want.Encode() {
+			t.Errorf("order query %d = %s, want %s", i, q.Encode(), want.Encode())
+		}
+	}
+
+	queries = nil
+	if _, err := client.ListWallets(WalletFilter{Chain: "ETH", CreateTimeOrder: SortAsc}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListTransactionDetails("b", "w", TxDetailFilter{TxTypes: []constants.TxType{constants.TxTypeDeposit}}, 10, 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := queries[0].Encode(); got != "chain=ETH&create_time_order=1" {
+		t.Errorf("wallet query = %s, an unset sort order must not be sent", got)
+	}
+	if got := queries[1].Encode(); got != "offset=10&tx_types=DEPOSIT" {
+		t.Errorf("tx detail query = %s", got)
+	}
+
+	_, err := client.ListTransactionDetails("b", "w", TxDetailFilter{Start: end, End: start}, 0, 0)
+	if !errors.Is(err, ErrInvalidTimeRange) || len(queries) != 2 {
+		t.Errorf("swapped time range error = %v after %d requests, want ErrInvalidTimeRange and no request", err, len(queries))
+	}
+}
@@ -0,0 +1,221 @@
+package cactus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+)
+
+// Logger receives structured log entries from the client
+// Every request is logged with the attributes method, path, nonce, status, latency and code.
+type Logger interface {
+	Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr)
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger adapts a *slog.Logger, nil uses slog.Default()
+// Request and response bodies are logged at debug level, so they stay hidden unless the handler enables it.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return slogLogger{logger: logger}
+}
+
+func (l slogLogger) Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// NopLogger discards everything
+type NopLogger struct{}
+
+func (NopLogger) Log(context.Context, slog.Level, string, ...slog.Attr) {}
+
+// Redaction selects what is masked before it reaches the Logger
+type Redaction struct {
+	APIKey        bool // x-api-key header
+	Authorization bool // Authorization header
+	Addresses     bool // json fields whose name contains "address", except address_type and the like
+	Amounts       bool // json fields whose name contains "amount", "balance", "value" or "fee"
+}
+
+// DefaultRedaction masks everything, used when Cactus.Redaction is nil
+var DefaultRedaction = Redaction{APIKey: true, Authorization: true, Addresses: true, Amounts: true}
+
+const redacted = "[REDACTED]"
+
+func (c *Cactus) logger() Logger {
+	if c.Logger == nil {
+		return NopLogger{}
+	}
+	return c.Logger
+}
+
+func (c *Cactus) redaction() Redaction {
+	if c.Redaction == nil {
+		return DefaultRedaction
+	}
+	return *c.Redaction
+}
+
+// log sends an entry to the configured Logger
+func (c *Cactus) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	c.logger().Log(ctx, level, msg, attrs...)
+}
+
+// Log writes an unstructured message
+// Deprecated: set Cactus.Logger instead, level 0 maps to debug and anything else to error.
+func (c *Cactus) Log(level int, message string) {
+	logLevel := slog.LevelError
+	if level == 0 {
+		logLevel = slog.LevelDebug
+	}
+	c.log(context.Background(), logLevel, message)
+}
+
+// redactHeader masks a credential header, keeping a short prefix so keys can still be told apart
+func redactHeader(value string, redact bool) string {
+	if !redact || value == "" {
+		return value
+	}
+	if len(value) <= 12 {
+		return redacted
+	}
+	return value[:4] + "..." + redacted
+}
+
+// redactBody masks addresses and amounts inside a json body, non json bodies are dropped when anything is redacted
+func redactBody(body []byte, r Redaction) string {
+	if len(body) == 0 || (!r.Addresses && !r.Amounts) {
+		return string(body)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return redacted
+	}
+	encoded, err := json.Marshal(redactValue("", decoded, r))
+	if err != nil {
+		return redacted
+	}
+	return string(encoded)
+}
+
+func redactValue(key string, value interface{}, r Redaction) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = redactValue(k, item, r)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(key, item, r)
+		}
+		return v
+	case nil:
+		return nil
+	}
+	if isSensitiveKey(key, r) {
+		return redacted
+	}
+	return value
+}
+
+func isSensitiveKey(key string, r Redaction) bool {
+	key = strings.ToLower(key)
+	if r.Addresses && strings.Contains(key, "address") {
+		// address_type, address_num, normal_address_limit... describe addresses without being one
+		for _, suffix := range []string{"_type", "_num", "_storage", "_format", "_limit"} {
+			if strings.HasSuffix(key, suffix) {
+				return false
+			}
+		}
+		return true
+	}
+	if r.Amounts {
+		for _, word := range []string{"amount", "balance", "value", "fee"} {
+			if strings.Contains(key, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redactPath masks addresses used as path segments, like /wallets/{code}/addresses/{address}
+func (c *Cactus) redactPath(path string) string {
+	if !c.redaction().Addresses || !strings.Contains(path, "/addresses/") {
+		return path
+	}
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i-1] == "addresses" && segments[i] != "apply" && segments[i] != "type" {
+			segments[i] = redacted
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// errorAttr logs err without the raw request path and query it may carry
+// APIError is logged field by field, its message only when addresses are not redacted since
+// cactus echoes rejected addresses there. Transport errors are logged with the redacted url.
+func (c *Cactus) errorAttr(err error) slog.Attr {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		attrs := []any{
+			slog.Int("status", apiErr.StatusCode),
+			slog.Int("code", apiErr.Code),
+			slog.String("nonce", apiErr.Nonce),
+			slog.String("path", c.redactPath(apiErr.Path)),
+		}
+		if apiErr.kind != nil {
+			attrs = append(attrs, slog.String("kind", apiErr.kind.Error()))
+		}
+		if !c.redaction().Addresses {
+			attrs = append(attrs, slog.String("message", apiErr.Message))
+		}
+		return slog.Group("error", attrs...)
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return slog.Group("error",
+			slog.String("op", urlErr.Op),
+			slog.String("url", c.redactURL(urlErr.URL)),
+			slog.Any("cause", urlErr.Err),
+		)
+	}
+	return slog.Any("error", err)
+}
+
+// redactURL masks the addresses in the path and query of a request url
+func (c *Cactus) redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	u.Path, u.RawPath = c.redactPath(u.Path), ""
+	if u.RawQuery != "" {
+		u.RawQuery = redactQuery(u.RawQuery, c.redaction())
+	}
+	return u.String()
+}
+
+// redactQuery masks address parameters of an encoded query
+func redactQuery(query string, r Redaction) string {
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return redacted
+	}
+	for key := range values {
+		if isSensitiveKey(key, r) {
+			values[key] = []string{redacted}
+		}
+	}
+	return values.Encode()
+}
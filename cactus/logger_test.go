@@ -0,0 +1,87 @@
+package cactus
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLoggerRedacts(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":200,"message":"success","successful":true,"data":{"address":"0xdeadbeef","total_amount":123456789}}`))
+	}))
+	var buf bytes.Buffer
+	client.Logger = NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	if _, err := client.GetSingleAddress("bid", "wallet", "", "0xdeadbeef"); err != nil {
+		t.Fatal(err)
+	}
+	logs := buf.String()
+	for _, want := range []string{`"method":"GET"`, `"status":200`, `"code":200`, `"latency"`, `"nonce"`, `/addresses/[REDACTED]`} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("logs miss %s:\n%s", want, logs)
+		}
+	}
+	for _, leaked := range []string{"test-api-key", "0xdeadbeef", "123456789", "test-key-id:"} {
+		if strings.Contains(logs, leaked) {
+			t.Errorf("logs leak %s:\n%s", leaked, logs)
+		}
+	}
+}
+
+func TestLoggerQuietAtInfo(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":200,"message":"success","successful":true,"data":[]}`))
+	}))
+	var buf bytes.Buffer
+	// The level of slog.Default(), which NewCactus logs to
+	client.Logger = NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	if _, err := client.GetCoinInfo("", ""); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("successful call logged at info level:\n%s", buf.String())
+	}
+}
+
+func TestLoggerRedactionDisabled(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":200,"message":"success","successful":true,"data":["0xdeadbeef"]}`))
+	}))
+	var buf bytes.Buffer
+	client.Logger = NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	client.Redaction = &Redaction{}
+	if _, err := client.VerifyAddress("ETH", []string{"0xdeadbeef"}); err != nil {
+		t.Fatal(err)
+	}
+	if logs := buf.String(); !strings.Contains(logs, "test-api-key") || !strings.Contains(logs, `\"addresses\":[\"0xdeadbeef\"]`) {
+		t.Errorf("logs are redacted although redaction is off:\n%s", logs)
+	}
+}
+
+func TestLoggerRedactsErrors(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":400,"message":"invalid address 0xdeadbeef","successful":false}`))
+	}))
+	var buf bytes.Buffer
+	client.Logger = NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	if _, err := client.GetSingleAddress("bid", "wallet", "", "0xdeadbeef"); err == nil {
+		t.Fatal("GetSingleAddress() error = nil")
+	}
+	// A transport error carries the full request url
+	client.BaseUri = "http://127.0.0.1:1"
+	if _, err := client.GetSingleAddress("bid", "wallet", "", "0xdeadbeef"); err == nil {
+		t.Fatal("GetSingleAddress() error = nil")
+	}
+	logs := buf.String()
+	for _, want := range []string{`"status":400`, `"kind":"cactus: invalid address"`, `"nonce"`, `/addresses/[REDACTED]`, `"op":"Get"`} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("logs miss %s:\n%s", want, logs)
+		}
+	}
+	if strings.Contains(logs, "0xdeadbeef") {
+		t.Errorf("logs leak the address:\n%s", logs)
+	}
+}
@@ -1,10 +1,10 @@
 package cactus
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
-	"strconv"
 )
 
 const (
@@ -14,51 +14,12 @@ const (
 	CancelOrderUrl      = "/custody/v1/api/projects/%s/orders/%s/cancel"
 )
 
-type GetFilteredOrderResp struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Data    struct {
-		Offset int `json:"offset"`
-		Limit  int `json:"limit"`
-		List   []struct {
-			DomainId                   string      `json:"domain_id"`
-			ExchangeRate               float64     `json:"exchange_rate"`
-			TimeStamp                  int64       `json:"time_stamp"`
-			OrderNo                    string      `json:"order_no"`
-			Applicant                  string      `json:"applicant"`
-			BusinessName               string      `json:"business_name"`
-			CoinName                   string      `json:"coin_name"`
-			StorageType                string      `json:"storage_type"`
-			WalletName                 string      `json:"wallet_name"`
-			WalletCode                 string      `json:"wallet_code"`
-			Amount                     int         `json:"amount"`
-			OriginalAmount             int         `json:"original_amount"`
-			MinerFeeRate               int         `json:"miner_fee_rate"`
-			Description                string      `json:"description"`
-			FromAddress                string      `json:"from_address"`
-			GasPrice                   interface{} `json:"gas_price"`
-			GasLimit                   interface{} `json:"gas_limit"`
-			OrderDestAddressInfoVoList []struct {
-				DestAddress   string `json:"dest_address"`
-				MemoType      string `json:"memo_type"`
-				Memo          string `json:"memo"`
-				OriginBalance int    `json:"origin_balance"`
-				Balance       int    `json:"balance"`
-				Remark        string `json:"remark"`
-			} `json:"order_dest_address_info_vo_list"`
-			Status      string `json:"status"`
-			InnerStatus string `json:"inner_status"`
-			Bid         string `json:"bid"`
-		} `json:"list"`
-		Total int `json:"total"`
-	} `json:"data"`
-	Successful bool `json:"successful"`
-}
+type GetFilteredOrderResp = Response[Page[Order]]
 
-// GetFilteredOrder gets the filtered order
+// GetFilteredOrder gets the filtered order, ListOrders takes the filters as an OrderFilter
 // bId: business id
-// startTime: start time, optional
-// endTime: end time, optional
+// startTime: start time in milliseconds, optional
+// endTime: end time in milliseconds, optional
 // keyword: keyword, optional
 // limit: limit, optional, default 10
 // offset: offset, optional, default 0
@@ -82,67 +43,55 @@ func (c *Cactus) GetFilteredOrder(
 	offset int,
 	limit int,
 ) (*GetFilteredOrderResp, error) {
-	params := map[string]string{}
-	if offset != 0 {
-		params["offset"] = fmt.Sprintf("%d", offset)
-	}
-	if limit != 0 {
-		params["limit"] = fmt.Sprintf("%d", limit)
-	}
-	if keyword != "" {
-		params["keyword"] = keyword
-	}
-	if startTime != 0 {
-		params["start_time"] = fmt.Sprintf("%d", startTime)
-	}
-	if endTime != 0 {
-		params["end_time"] = fmt.Sprintf("%d", endTime)
-	}
-	if sortByTime != constants.OrderTypeNotUsed {
-		params["sort_by_time"] = strconv.Itoa(int(sortByTime))
-	}
-	if len(applicant) > 0 {
-		applicantString := ""
-		for _, app := range applicant {
-			applicantString += app + ","
-		}
-		applicantString = applicantString[:len(applicantString)-1]
-		params["applicant"] = applicantString
-	}
-	if len(coinName) > 0 {
-		coinNameString := ""
-		for _, coin := range coinName {
-			coinNameString += string(coin) + ","
-		}
-		coinNameString = coinNameString[:len(coinNameString)-1]
-		params["coin_name"] = coinNameString
-	}
-	if len(chainName) > 0 {
-		chainNameString := ""
-		for _, chain := range chainName {
-			chainNameString += string(chain) + ","
-		}
-		chainNameString = chainNameString[:len(chainNameString)-1]
-		params["chain_name"] = chainNameString
-	}
-	if len(walletName) > 0 {
-		walletNameString := ""
-		for _, wallet := range walletName {
-			walletNameString += wallet + ","
-		}
-		walletNameString = walletNameString[:len(walletNameString)-1]
-		params["wallet_name"] = walletNameString
-	}
-	if len(status) > 0 {
-		statusString := ""
-		for _, stat := range status {
-			statusString += stat + ","
-		}
-		statusString = statusString[:len(statusString)-1]
-		params["status"] = statusString
+	return c.GetFilteredOrderWithContext(context.Background(), bId, applicant, coinName, chainName, walletName, status, keyword, sortByTime, startTime, endTime, offset, limit)
+}
+
+// GetFilteredOrderWithContext is GetFilteredOrder with a caller supplied context.
+func (c *Cactus) GetFilteredOrderWithContext(
+	ctx context.Context,
+	bId string,
+	applicant []string,
+	coinName []constants.CactusToken,
+	chainName []constants.ChainName,
+	walletName []string,
+	status []string,
+	keyword string,
+	sortByTime constants.OrderType,
+	startTime int,
+	endTime int,
+	offset int,
+	limit int,
+) (*GetFilteredOrderResp, error) {
+	return c.ListOrdersWithContext(ctx, bId, OrderFilter{
+		Applicants:  applicant,
+		CoinNames:   coinName,
+		Chains:      chainName,
+		WalletNames: walletName,
+		Statuses:    status,
+		Keyword:     keyword,
+		SortByTime:  sortOrderOf(sortByTime),
+		Start:       timeOf(int64(startTime)),
+		End:         timeOf(int64(endTime)),
+	}, offset, limit)
+}
+
+// ListOrders gets one page of the orders matching filter
+// bId: business id
+// offset: offset, optional, default 0
+// limit: limit, optional, default 10
+func (c *Cactus) ListOrders(bId string, filter OrderFilter, offset int, limit int) (*GetFilteredOrderResp, error) {
+	return c.ListOrdersWithContext(context.Background(), bId, filter, offset, limit)
+}
+
+// ListOrdersWithContext is ListOrders with a caller supplied context.
+func (c *Cactus) ListOrdersWithContext(ctx context.Context, bId string, filter OrderFilter, offset int, limit int) (*GetFilteredOrderResp, error) {
+	params, err := filter.values()
+	if err != nil {
+		return nil, err
 	}
+	setPage(params, offset, limit)
 	path := fmt.Sprintf(GetFilteredOrderUrl, bId)
-	resp, err := c.get(path, params)
+	resp, err := c.get(ctx, path, params)
 	if err != nil {
 		return nil, err
 	}
@@ -154,77 +103,16 @@ func (c *Cactus) GetFilteredOrder(
 	return &getFilteredOrderResp, nil
 }
 
-type GetOrderDetailsResp struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Data    struct {
-		OrderWalletInfo struct {
-			BusinessName               string      `json:"business_name"`
-			CoinName                   string      `json:"coin_name"`
-			WalletType                 string      `json:"wallet_type"`
-			StorageType                string      `json:"storage_type"`
-			WalletName                 string      `json:"wallet_name"`
-			WalletCode                 string      `json:"wallet_code"`
-			Timestamp                  int64       `json:"timestamp"`
-			OrderNo                    string      `json:"order_no"`
-			Applicant                  string      `json:"applicant"`
-			Amount                     int         `json:"amount"`
-			ExchangeRate               int         `json:"exchange_rate"`
-			Value                      interface{} `json:"value"`
-			MinerFeeRate               interface{} `json:"miner_fee_rate"`
-			Description                string      `json:"description"`
-			Status                     string      `json:"status"`
-			InnerStatus                string      `json:"inner_status"`
-			MinerFee                   int64       `json:"miner_fee"`
-			FromAddress                string      `json:"from_address"`
-			GasPrice                   int64       `json:"gas_price"`
-			GasLimit                   int         `json:"gas_limit"`
-			OrderDestAddressInfoVoList []struct {
-				DestAddress   string `json:"dest_address"`
-				MemoType      string `json:"memo_type"`
-				Memo          string `json:"memo"`
-				OriginBalance int    `json:"origin_balance"`
-				Balance       int    `json:"balance"`
-				Remark        string `json:"remark"`
-			} `json:"order_dest_address_info_vo_list"`
-			Bid string `json:"bid"`
-		} `json:"order_wallet_info"`
-		TxInfoModels []struct {
-			TxType      string `json:"tx_type"`
-			BlockHeight int    `json:"block_height"`
-			TxSize      int    `json:"tx_size"`
-			TxHash      string `json:"tx_hash"`
-			GasPrice    int64  `json:"gas_price"`
-			GasLimit    int    `json:"gas_limit"`
-			MinerFee    string `json:"miner_fee"`
-		} `json:"tx_info_models"`
-		ConsolidationTxInfoModels []struct {
-			TxType      string `json:"tx_type"`
-			BlockHeight int    `json:"block_height"`
-			TxSize      int    `json:"tx_size"`
-			TxHash      string `json:"tx_hash"`
-			GasPrice    int64  `json:"gas_price"`
-			GasLimit    int    `json:"gas_limit"`
-			MinerFee    string `json:"miner_fee"`
-		} `json:"consolidation_tx_info_models"`
-		MinerFeeTxInfoModels []struct {
-			TxType      string      `json:"tx_type"`
-			BlockHeight int         `json:"block_height"`
-			TxSize      int         `json:"tx_size"`
-			TxHash      string      `json:"tx_hash"`
-			GasPrice    int64       `json:"gas_price"`
-			GasLimit    interface{} `json:"gas_limit"`
-			MinerFee    string      `json:"miner_fee"`
-		} `json:"miner_fee_tx_info_models"`
-		PartialFailed  []interface{} `json:"partial_failed"`
-		PartialSuccess []interface{} `json:"partial_success"`
-	} `json:"data"`
-	Successful bool `json:"successful"`
-}
+type GetOrderDetailsResp = Response[OrderDetails]
 
 func (c *Cactus) GetOrderDetails(bId string, orderNo string) (*GetOrderDetailsResp, error) {
+	return c.GetOrderDetailsWithContext(context.Background(), bId, orderNo)
+}
+
+// GetOrderDetailsWithContext is GetOrderDetails with a caller supplied context.
+func (c *Cactus) GetOrderDetailsWithContext(ctx context.Context, bId string, orderNo string) (*GetOrderDetailsResp, error) {
 	path := fmt.Sprintf(GetOrderDetailsUrl, bId, orderNo)
-	resp, err := c.get(path, nil)
+	resp, err := c.get(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -236,11 +124,12 @@ func (c *Cactus) GetOrderDetails(bId string, orderNo string) (*GetOrderDetailsRe
 	return &getOrderDetailsResp, nil
 }
 
-type ReplaceByFeeResp struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	Data       int64  `json:"data"` // GasPrice
-	Successful bool   `json:"successful"`
+type ReplaceByFeeResp = Response[int64]
+
+// feeLevelReq is the body of accelerate and cancel, gas_price is only sent for a custom level
+type feeLevelReq struct {
+	GasPrice float64                     `json:"gas_price,omitempty"`
+	Level    constants.ReplaceByFeeLevel `json:"level"`
 }
 
 // ReplaceByFee replaces the order by fee
@@ -249,14 +138,14 @@ type ReplaceByFeeResp struct {
 // level: replace by fee level
 // gasPrice: gas price, required if level is custom
 func (c *Cactus) ReplaceByFee(bId string, orderNo string, level constants.ReplaceByFeeLevel, gasPrice float64) (*ReplaceByFeeResp, error) {
-	params := map[string]interface{}{
-		"level": level,
-	}
-	if gasPrice != 0 {
-		params["gas_price"] = gasPrice
-	}
+	return c.ReplaceByFeeWithContext(context.Background(), bId, orderNo, level, gasPrice)
+}
+
+// ReplaceByFeeWithContext is ReplaceByFee with a caller supplied context.
+func (c *Cactus) ReplaceByFeeWithContext(ctx context.Context, bId string, orderNo string, level constants.ReplaceByFeeLevel, gasPrice float64) (*ReplaceByFeeResp, error) {
+	params := feeLevelReq{GasPrice: gasPrice, Level: level}
 	path := fmt.Sprintf(ReplaceByFeeUrl, bId, orderNo)
-	resp, err := c.post(path, params)
+	resp, err := c.post(ctx, path, params)
 	if err != nil {
 		return nil, err
 	}
@@ -268,12 +157,7 @@ func (c *Cactus) ReplaceByFee(bId string, orderNo string, level constants.Replac
 	return &replaceByFeeResp, nil
 }
 
-type CancelOrderResp struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	Data       int64  `json:"data"` // GasPrice
-	Successful bool   `json:"successful"`
-}
+type CancelOrderResp = Response[int64]
 
 // CancelOrder cancels the order
 // bId: business id
@@ -281,14 +165,14 @@ type CancelOrderResp struct {
 // level: replace by fee level
 // gasPrice: gas price, required if level is custom
 func (c *Cactus) CancelOrder(bId string, orderNo string, level constants.ReplaceByFeeLevel, gasPrice float64) (*CancelOrderResp, error) {
+	return c.CancelOrderWithContext(context.Background(), bId, orderNo, level, gasPrice)
+}
+
+// CancelOrderWithContext is CancelOrder with a caller supplied context.
+func (c *Cactus) CancelOrderWithContext(ctx context.Context, bId string, orderNo string, level constants.ReplaceByFeeLevel, gasPrice float64) (*CancelOrderResp, error) {
 	path := fmt.Sprintf(CancelOrderUrl, bId, orderNo)
-	param := map[string]interface{}{
-		"level": level,
-	}
-	if gasPrice != 0 {
-		param["gas_price"] = gasPrice
-	}
-	resp, err := c.post(path, param)
+	param := feeLevelReq{GasPrice: gasPrice, Level: level}
+	resp, err := c.post(ctx, path, param)
 	if err != nil {
 		return nil, err
 	}
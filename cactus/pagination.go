@@ -0,0 +1,127 @@
+package cactus
+
+import (
+	"context"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"iter"
+	"log/slog"
+	"strconv"
+)
+
+// DefaultPageSize is the page size of the list iterators when they are given 0
+const DefaultPageSize = 50
+
+// pageFetcher fetches the page starting at offset
+type pageFetcher[T any] func(ctx context.Context, offset int, limit int) (*Response[Page[T]], error)
+
+// paginate walks all pages lazily, one request per page, as the caller ranges over the sequence
+// Items appearing again on a later page, because new ones were inserted in front of them, are skipped
+// by their key. An error, including a cancelled ctx, is yielded once and ends the sequence.
+func paginate[T any](ctx context.Context, c *Cactus, pageSize int, key func(T) string, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		seen := map[string]struct{}{}
+		offset := 0
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			resp, err := fetch(ctx, offset, pageSize)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			page := resp.Data
+			shifted := 0
+			for _, item := range page.List {
+				k := key(item)
+				if _, ok := seen[k]; ok {
+					shifted++
+					continue
+				}
+				seen[k] = struct{}{}
+				if err = ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if shifted > 0 {
+				c.log(ctx, slog.LevelDebug, "cactus page shifted", slog.Int("offset", offset), slog.Int("repeated", shifted))
+			}
+			offset += len(page.List)
+			// Total is authoritative when present, a short page ends lists that do not report it
+			if len(page.List) == 0 || (page.Total > 0 && offset >= page.Total) || (page.Total == 0 && len(page.List) < pageSize) {
+				return
+			}
+		}
+	}
+}
+
+func walletKey(w Wallet) string               { return w.WalletCode + "/" + w.CoinName }
+func addressKey(a Address) string             { return a.Address + "/" + a.CoinName }
+func orderKey(o Order) string                 { return o.OrderNo }
+func contractOrderKey(o ContractOrder) string { return o.OrderNo }
+func txDetailKey(d WalletTxDetail) string     { return strconv.Itoa(d.Id) }
+
+// txSummaryKey identifies a history entry, one transaction can credit several coins or move both ways
+func txSummaryKey(s WalletTxSummary) string {
+	return s.TxId + "/" + s.CoinName + "/" + s.TxType + "/" + s.OrderNo
+}
+
+// IterWalletList iterates over all wallets matching filter, pageSize 0 uses DefaultPageSize
+func (c *Cactus) IterWalletList(ctx context.Context, filter WalletFilter, pageSize int) iter.Seq2[Wallet, error] {
+	return paginate(ctx, c, pageSize, walletKey, func(ctx context.Context, offset int, limit int) (*Response[Page[Wallet]], error) {
+		return c.ListWalletsWithContext(ctx, filter, offset, limit)
+	})
+}
+
+// IterAddressList iterates over all addresses of a wallet, see GetAddressList
+func (c *Cactus) IterAddressList(ctx context.Context, bId string, walletCode string, coinName constants.CactusToken, hideNoCoinAddress bool, keyword string, pageSize int) iter.Seq2[Address, error] {
+	return paginate(ctx, c, pageSize, addressKey, func(ctx context.Context, offset int, limit int) (*Response[Page[Address]], error) {
+		return c.GetAddressListWithContext(ctx, bId, walletCode, coinName, hideNoCoinAddress, keyword, offset, limit)
+	})
+}
+
+// IterFilteredOrder iterates over all orders matching filter
+func (c *Cactus) IterFilteredOrder(ctx context.Context, bId string, filter OrderFilter, pageSize int) iter.Seq2[Order, error] {
+	return paginate(ctx, c, pageSize, orderKey, func(ctx context.Context, offset int, limit int) (*Response[Page[Order]], error) {
+		return c.ListOrdersWithContext(ctx, bId, filter, offset, limit)
+	})
+}
+
+// IterWalletTransactionHistory iterates over the whole wallet transaction history, see GetWalletTransactionHistory
+func (c *Cactus) IterWalletTransactionHistory(ctx context.Context,
+	bId string,
+	walletCode string,
+	coinName constants.CactusToken,
+	txTypes []constants.TxType,
+	addresses []string,
+	createTimeOrder constants.OrderType,
+	startTime int64,
+	endTime int64,
+	pageSize int) iter.Seq2[WalletTxSummary, error] {
+	return paginate(ctx, c, pageSize, txSummaryKey, func(ctx context.Context, offset int, limit int) (*Response[Page[WalletTxSummary]], error) {
+		return c.GetWalletTransactionHistoryWithContext(ctx, bId, walletCode, coinName, txTypes, addresses, offset, limit, createTimeOrder, startTime, endTime)
+	})
+}
+
+// IterTransactionDetails iterates over all transactions of a wallet matching filter
+func (c *Cactus) IterTransactionDetails(ctx context.Context, bId string, walletCode string, filter TxDetailFilter, pageSize int) iter.Seq2[WalletTxDetail, error] {
+	return paginate(ctx, c, pageSize, txDetailKey, func(ctx context.Context, offset int, limit int) (*Response[Page[WalletTxDetail]], error) {
+		return c.ListTransactionDetailsWithContext(ctx, bId, walletCode, filter, offset, limit)
+	})
+}
+
+// IterTransactionHistory iterates over all contract orders of a defi wallet, see GetTransactionHistory
+func (c *Cactus) IterTransactionHistory(ctx context.Context, bId string, walletCode string, keyword string, sortByTime string, status string, chain constants.ChainName, startTime int64, pageSize int) iter.Seq2[ContractOrder, error] {
+	return paginate(ctx, c, pageSize, contractOrderKey, func(ctx context.Context, offset int, limit int) (*Response[Page[ContractOrder]], error) {
+		return c.GetTransactionHistoryWithContext(ctx, bId, walletCode, keyword, sortByTime, status, chain, startTime, limit, offset)
+	})
+}
@@ -0,0 +1,101 @@
+package cactus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// orderPages serves orders newest first, like cactus does
+type orderPages struct {
+	mu       sync.Mutex
+	orders   []string
+	requests int
+	// onPage runs after each page was served
+	onPage func(p *orderPages)
+}
+
+func (p *orderPages) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests++
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	page := Page[Order]{Offset: offset, Limit: limit, Total: len(p.orders), List: []Order{}}
+	for i := offset; i < len(p.orders) && i < offset+limit; i++ {
+		page.List = append(page.List, Order{OrderNo: p.orders[i]})
+	}
+	json.NewEncoder(w).Encode(Response[Page[Order]]{Code: 200, Successful: true, Data: page})
+	if p.onPage != nil {
+		p.onPage(p)
+	}
+}
+
+func collectOrders(t *testing.T, seq func(yield func(Order, error) bool)) ([]string, error) {
+	t.Helper()
+	var got []string
+	for order, err := range seq {
+		if err != nil {
+			return got, err
+		}
+		got = append(got, order.OrderNo)
+	}
+	return got, nil
+}
+
+func TestIterFilteredOrder(t *testing.T) {
+	pages := &orderPages{}
+	for i := 7; i >= 1; i-- {
+		pages.orders = append(pages.orders, fmt.Sprintf("o%d", i))
+	}
+	// Two new orders arrive after the first page, pushing o5 and o4 onto the second page again
+	pages.onPage = func(p *orderPages) {
+		if p.requests == 1 {
+			p.orders = append([]string{"o9", "o8"}, p.orders...)
+		}
+	}
+	client := newTestClient(t, pages)
+	got, err := collectOrders(t, client.IterFilteredOrder(context.Background(), "b", OrderFilter{}, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(got) != "[o7 o6 o5 o4 o3 o2 o1]" {
+		t.Errorf("orders = %v, want each original order once", got)
+	}
+	if pages.requests != 3 {
+		t.Errorf("%d requests, want 3", pages.requests)
+	}
+}
+
+func TestIterStopsEarly(t *testing.T) {
+	pages := &orderPages{orders: []string{"o3", "o2", "o1"}}
+	client := newTestClient(t, pages)
+	for range client.IterFilteredOrder(context.Background(), "b", OrderFilter{}, 1) {
+		break
+	}
+	if pages.requests != 1 {
+		t.Errorf("%d requests after break, want 1", pages.requests)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pages.requests = 0
+	var got []string
+	var iterErr error
+	for order, err := range client.IterFilteredOrder(ctx, "b", OrderFilter{}, 2) {
+		if err != nil {
+			iterErr = err
+			continue
+		}
+		got = append(got, order.OrderNo)
+		cancel()
+	}
+	if !errors.Is(iterErr, context.Canceled) || len(got) != 1 || pages.requests != 1 {
+		t.Errorf("after cancel got %v, error %v, %d requests", got, iterErr, pages.requests)
+	}
+}
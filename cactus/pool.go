@@ -0,0 +1,266 @@
+package cactus
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/utils"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrUnknownTenant   = errors.New("cactus: unknown tenant")
+	ErrDuplicateTenant = errors.New("cactus: duplicate tenant")
+)
+
+// Credentials are the base uri and keys a client signs its requests with
+// Signer takes precedence over PrivateKey, like on Cactus.
+type Credentials struct {
+	BaseUri    string
+	XApiKey    string
+	ApiKeyID   string
+	PrivateKey *ecdsa.PrivateKey
+	Signer     utils.Signer
+}
+
+func (c Credentials) validate() error {
+	var errs []error
+	if c.BaseUri == "" {
+		errs = append(errs, errors.New("base uri is not set"))
+	}
+	if c.XApiKey == "" {
+		errs = append(errs, errors.New("api key is not set"))
+	}
+	if c.ApiKeyID == "" {
+		errs = append(errs, errors.New("api key id is not set"))
+	}
+	if c.PrivateKey == nil && c.Signer == nil {
+		errs = append(errs, errors.New("neither private key nor signer is set"))
+	}
+	return errors.Join(errs...)
+}
+
+// Tenant is one credential set managed by a Pool, e.g. the api key of one domain
+type Tenant struct {
+	Name string
+	// BIds are the business ids served by this api key, the pool can look the tenant up by each
+	BIds        []string
+	Credentials Credentials
+	// Limits and MaxInFlight configure the tenant's own Limiter, see NewLimiter, nil Limits and 0
+	// MaxInFlight leave the tenant unthrottled
+	Limits      map[EndpointGroup]RateLimit
+	MaxInFlight int
+	// Retry is the retry policy of the tenant's client, nil disables retries
+	Retry *RetryPolicy
+}
+
+// Pool holds one client per tenant, all sharing a single http client and its connections
+// Every tenant is throttled by its own Limiter and logs through the pool logger with tenant and
+// b_id attributes. Rotate swaps the credentials of a tenant atomically: calls already holding the
+// old clients finish with the old credentials, later lookups get the new clients.
+// A Pool is safe for concurrent use.
+type Pool struct {
+	httpClient *http.Client
+	logger     Logger
+
+	mu      sync.RWMutex
+	tenants map[string]*pooledTenant
+	byBId   map[string]*pooledTenant
+}
+
+type pooledTenant struct {
+	tenant  Tenant
+	limiter *Limiter
+	logger  Logger
+	clients atomic.Pointer[tenantClients]
+}
+
+// tenantClients are the clients of one credential set, the tenant one and one per business id
+// They live until the next Rotate, so each keeps its clock skew and token registry across lookups.
+type tenantClients struct {
+	tenant *Cactus
+	byBId  map[string]*Cactus
+}
+
+// NewPoolTransport returns a transport tuned for many tenants calling the same cactus host
+// The default transport keeps only two idle connections per host, which makes concurrent tenants
+// reconnect constantly.
+func NewPoolTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = 10 * time.Second
+	transport.MaxIdleConns = 256
+	transport.MaxIdleConnsPerHost = 64
+	transport.IdleConnTimeout = 90 * time.Second
+	transport.ForceAttemptHTTP2 = true
+	return transport
+}
+
+// NewPool creates an empty pool
+// httpClient: shared by every tenant, nil uses a client with NewPoolTransport and a one minute timeout
+// logger: receives the logs of every tenant, nil discards them
+func NewPool(httpClient *http.Client, logger Logger) *Pool {
+	if httpClient == nil {
+		httpClient = &http.Client{Transport: NewPoolTransport(), Timeout: time.Minute}
+	}
+	if logger == nil {
+		logger = NopLogger{}
+	}
+	return &Pool{
+		httpClient: httpClient,
+		logger:     logger,
+		tenants:    map[string]*pooledTenant{},
+		byBId:      map[string]*pooledTenant{},
+	}
+}
+
+// Add registers a tenant, its name and business ids must not be taken by another tenant
+func (p *Pool) Add(t Tenant) error {
+	if t.Name == "" {
+		return errors.New("cactus: tenant name is not set")
+	}
+	if err := t.Credentials.validate(); err != nil {
+		return fmt.Errorf("cactus: tenant %s: %w", t.Name, err)
+	}
+	t.BIds = slices.Clone(t.BIds)
+	pt := &pooledTenant{
+		tenant:  t,
+		limiter: NewLimiter(t.Limits, t.MaxInFlight),
+		logger:  tenantLogger{logger: p.logger, attrs: []slog.Attr{slog.String("tenant", t.Name)}},
+	}
+	pt.clients.Store(p.newClients(pt, t.Credentials))
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.tenants[t.Name]; ok {
+		return fmt.Errorf("%w %s", ErrDuplicateTenant, t.Name)
+	}
+	for _, bId := range t.BIds {
+		if other, ok := p.byBId[bId]; ok {
+			return fmt.Errorf("%w: b_id %s is served by %s", ErrDuplicateTenant, bId, other.tenant.Name)
+		}
+	}
+	p.tenants[t.Name] = pt
+	for _, bId := range t.BIds {
+		p.byBId[bId] = pt
+	}
+	return nil
+}
+
+func (p *Pool) newClients(pt *pooledTenant, creds Credentials) *tenantClients {
+	clients := &tenantClients{tenant: p.newClient(pt, creds, pt.logger), byBId: map[string]*Cactus{}}
+	for _, bId := range pt.tenant.BIds {
+		logger := tenantLogger{logger: pt.logger, attrs: []slog.Attr{slog.String("b_id", bId)}}
+		clients.byBId[bId] = p.newClient(pt, creds, logger)
+	}
+	return clients
+}
+
+func (p *Pool) newClient(pt *pooledTenant, creds Credentials, logger Logger) *Cactus {
+	return &Cactus{
+		BaseUri:    creds.BaseUri,
+		XApiKey:    creds.XApiKey,
+		ApiKeyID:   creds.ApiKeyID,
+		PrivateKey: creds.PrivateKey,
+		Signer:     creds.Signer,
+		HttpClient: p.httpClient,
+		Logger:     logger,
+		Retry:      pt.tenant.Retry,
+		Limiter:    pt.limiter,
+	}
+}
+
+// Remove drops a tenant, calls already holding its client are not affected
+func (p *Pool) Remove(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pt, ok := p.tenants[name]
+	if !ok {
+		return
+	}
+	delete(p.tenants, name)
+	for _, bId := range pt.tenant.BIds {
+		delete(p.byBId, bId)
+	}
+}
+
+// Client returns the current client of the named tenant
+func (p *Pool) Client(name string) (*Cactus, error) {
+	p.mu.RLock()
+	pt, ok := p.tenants[name]
+	p.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownTenant, name)
+	}
+	return pt.clients.Load().tenant, nil
+}
+
+// ClientForBId returns the current client of the tenant serving the business id
+// The client logs with a b_id attribute on top of the tenant one. Every lookup returns the same
+// client until the tenant is rotated.
+func (p *Pool) ClientForBId(bId string) (*Cactus, error) {
+	p.mu.RLock()
+	pt, ok := p.byBId[bId]
+	p.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w for b_id %s", ErrUnknownTenant, bId)
+	}
+	return pt.clients.Load().byBId[bId], nil
+}
+
+// Rotate replaces the credentials of a tenant
+// The new client keeps the tenant's limiter, so the rate limits hold across the rotation.
+func (p *Pool) Rotate(name string, creds Credentials) error {
+	if err := creds.validate(); err != nil {
+		return fmt.Errorf("cactus: tenant %s: %w", name, err)
+	}
+	p.mu.RLock()
+	pt, ok := p.tenants[name]
+	p.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w %s", ErrUnknownTenant, name)
+	}
+	pt.clients.Store(p.newClients(pt, creds))
+	pt.logger.Log(context.Background(), slog.LevelInfo, "cactus tenant credentials rotated", slog.String("api_key_id", creds.ApiKeyID))
+	return nil
+}
+
+// Tenants returns the names of all tenants, sorted
+func (p *Pool) Tenants() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	names := make([]string, 0, len(p.tenants))
+	for name := range p.tenants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LimiterStats returns the wait statistics of the named tenant's limiter
+func (p *Pool) LimiterStats(name string) (map[EndpointGroup]LimiterStats, error) {
+	p.mu.RLock()
+	pt, ok := p.tenants[name]
+	p.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownTenant, name)
+	}
+	return pt.limiter.Stats(), nil
+}
+
+// tenantLogger adds the tenant attributes to every entry
+type tenantLogger struct {
+	logger Logger
+	attrs  []slog.Attr
+}
+
+func (l tenantLogger) Log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	l.logger.Log(ctx, level, msg, append(slices.Clone(l.attrs), attrs...)...)
+}
@@ -0,0 +1,107 @@
+package cactus
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testCredentials(t *testing.T, baseUri string, apiKey string) Credentials {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Credentials{BaseUri: baseUri, XApiKey: apiKey, ApiKeyID: apiKey + "-id", PrivateKey: key}
+}
+
+func TestPoolRotatesWithoutDroppingCalls(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	blocked, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("x-api-key"))
+		mu.Unlock()
+		if r.URL.Query().Get("cactus_symbol") == "SLOW" {
+			close(blocked)
+			<-release
+		}
+		w.Write([]byte(`{"code":0,"successful":true,"data":[]}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	pool := NewPool(server.Client(), NewSlogLogger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	err := pool.Add(Tenant{Name: "desk", BIds: []string{"1", "2"}, Credentials: testCredentials(t, server.URL, "old-key"),
+		Limits: map[EndpointGroup]RateLimit{EndpointGroupRead: {Rate: 1000, Burst: 10}}})
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err = pool.Add(Tenant{Name: "other", BIds: []string{"2"}, Credentials: testCredentials(t, server.URL, "other-key")}); !errors.Is(err, ErrDuplicateTenant) {
+		t.Errorf("Add() with a taken b_id error = %v, want %v", err, ErrDuplicateTenant)
+	}
+
+	old, err := pool.ClientForBId("2")
+	if err != nil {
+		t.Fatalf("ClientForBId() error = %v", err)
+	}
+	if again, _ := pool.ClientForBId("2"); again != old {
+		t.Error("ClientForBId() built a new client for the same b_id")
+	}
+	done := make(chan error)
+	go func() {
+		_, err := old.GetCoinInfo("SLOW", "")
+		done <- err
+	}()
+	<-blocked
+	if err = pool.Rotate("desk", testCredentials(t, server.URL, "new-key")); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	current, err := pool.Client("desk")
+	if err != nil {
+		t.Fatalf("Client() error = %v", err)
+	}
+	if current.Limiter != old.Limiter {
+		t.Error("Rotate() replaced the tenant limiter")
+	}
+	if rotated, _ := pool.ClientForBId("2"); rotated == old || rotated.XApiKey != "new-key" {
+		t.Error("ClientForBId() after Rotate() returned the old client")
+	}
+	if _, err = current.GetCoinInfo("BTC", ""); err != nil {
+		t.Errorf("GetCoinInfo() after Rotate() error = %v", err)
+	}
+	close(release)
+	if err = <-done; err != nil {
+		t.Errorf("in-flight GetCoinInfo() error = %v", err)
+	}
+	mu.Lock()
+	if strings.Join(keys, ",") != "old-key,new-key" {
+		t.Errorf("api keys sent = %v, want the old one for the in-flight call", keys)
+	}
+	mu.Unlock()
+	if stats, _ := pool.LimiterStats("desk"); stats[EndpointGroupRead].Calls != 2 {
+		t.Errorf("LimiterStats() = %+v, want both calls on the tenant limiter", stats)
+	}
+	for _, want := range []string{`"tenant":"desk"`, `"b_id":"2"`, `"api_key_id":"new-key-id"`} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("logs miss %s:\n%s", want, logs.String())
+		}
+	}
+
+	pool.Remove("desk")
+	if _, err = pool.ClientForBId("1"); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("ClientForBId() after Remove() error = %v, want %v", err, ErrUnknownTenant)
+	}
+	if got := pool.Tenants(); len(got) != 0 {
+		t.Errorf("Tenants() = %v, want none", got)
+	}
+}
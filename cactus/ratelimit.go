@@ -0,0 +1,194 @@
+package cactus
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointGroup groups endpoints sharing a rate limit
+type EndpointGroup string
+
+const (
+	// EndpointGroupRead covers every GET call and the POST calls that neither create orders nor sign
+	EndpointGroupRead EndpointGroup = "read"
+	// EndpointGroupOrder covers withdrawal and contract order creation, acceleration and cancellation
+	EndpointGroupOrder EndpointGroup = "order"
+	// EndpointGroupSignature covers signature order creation
+	EndpointGroupSignature EndpointGroup = "signature"
+)
+
+// endpointGroupOf maps a request to its EndpointGroup
+func endpointGroupOf(method string, path string) EndpointGroup {
+	if method == http.MethodGet {
+		return EndpointGroupRead
+	}
+	switch {
+	case strings.HasSuffix(path, "/signatures"):
+		return EndpointGroupSignature
+	case strings.HasSuffix(path, "/order/create"), strings.HasSuffix(path, "/contract/call"),
+		strings.HasSuffix(path, "/accelerate"), strings.HasSuffix(path, "/cancel"):
+		return EndpointGroupOrder
+	}
+	return EndpointGroupRead
+}
+
+// RateLimit is a token bucket configuration
+// Rate: tokens added per second
+// Burst: bucket size, at least 1
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// LimiterStats reports how long calls of a group waited for the limiter
+type LimiterStats struct {
+	Calls     int64         // calls that passed the limiter
+	Delayed   int64         // calls that had to wait
+	TotalWait time.Duration // sum of all waits
+	MaxWait   time.Duration // longest single wait
+}
+
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes a token and returns how long the caller has to wait before using it
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// refund returns the token of a reservation that was not used
+func (b *tokenBucket) refund() {
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// Limiter is a client side token bucket per EndpointGroup combined with a max in flight semaphore
+// A Limiter is safe for concurrent use and may be shared by several clients.
+type Limiter struct {
+	mu       sync.Mutex
+	buckets  map[EndpointGroup]*tokenBucket
+	stats    map[EndpointGroup]*LimiterStats
+	inFlight chan struct{}
+}
+
+// NewLimiter creates a limiter
+// limits: rate limit per group, groups without an entry are not rate limited
+// maxInFlight: max concurrent requests, 0 for unlimited
+func NewLimiter(limits map[EndpointGroup]RateLimit, maxInFlight int) *Limiter {
+	l := &Limiter{
+		buckets: map[EndpointGroup]*tokenBucket{},
+		stats:   map[EndpointGroup]*LimiterStats{},
+	}
+	now := time.Now()
+	for group, limit := range limits {
+		if limit.Rate <= 0 {
+			continue
+		}
+		burst := float64(limit.Burst)
+		if burst < 1 {
+			burst = 1
+		}
+		l.buckets[group] = &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst, last: now}
+	}
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// Wait blocks until a call of the given group may be sent
+// The returned release func must be called once the call completed.
+func (l *Limiter) Wait(ctx context.Context, group EndpointGroup) (release func(), waited time.Duration, err error) {
+	start := time.Now()
+	l.mu.Lock()
+	bucket := l.buckets[group]
+	var delay time.Duration
+	if bucket != nil {
+		delay = bucket.reserve(start)
+	}
+	l.mu.Unlock()
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.refund(bucket)
+			return nil, time.Since(start), ctx.Err()
+		case <-timer.C:
+		}
+	}
+	release = func() {}
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			// The call is not sent, its token is still unused
+			l.refund(bucket)
+			return nil, time.Since(start), ctx.Err()
+		}
+		var once sync.Once
+		release = func() {
+			once.Do(func() { <-l.inFlight })
+		}
+	}
+	waited = time.Since(start)
+	l.record(group, waited)
+	return release, waited, nil
+}
+
+func (l *Limiter) refund(bucket *tokenBucket) {
+	if bucket == nil {
+		return
+	}
+	l.mu.Lock()
+	bucket.refund()
+	l.mu.Unlock()
+}
+
+func (l *Limiter) record(group EndpointGroup, waited time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := l.stats[group]
+	if stats == nil {
+		stats = &LimiterStats{}
+		l.stats[group] = stats
+	}
+	stats.Calls++
+	// Sub millisecond waits are lock contention, not throttling
+	if waited >= time.Millisecond {
+		stats.Delayed++
+		stats.TotalWait += waited
+		if waited > stats.MaxWait {
+			stats.MaxWait = waited
+		}
+	}
+}
+
+// Stats returns a snapshot of the wait statistics per group
+func (l *Limiter) Stats() map[EndpointGroup]LimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	result := make(map[EndpointGroup]LimiterStats, len(l.stats))
+	for group, stats := range l.stats {
+		result[group] = *stats
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package cactus
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEndpointGroupOf(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   EndpointGroup
+	}{
+		{http.MethodGet, "/custody/v1/api/projects/b/orders/o", EndpointGroupRead},
+		{http.MethodPost, "/custody/v1/api/projects/b/order/create", EndpointGroupOrder},
+		{http.MethodPost, "/custody/v1/api/projects/b/contract/call", EndpointGroupOrder},
+		{http.MethodPost, "/custody/v1/api/projects/b/orders/o/cancel", EndpointGroupOrder},
+		{http.MethodPost, "/custody/v1/api/projects/b/wallets/w/signatures", EndpointGroupSignature},
+		{http.MethodPost, "/custody/v1/api/addresses/type/check", EndpointGroupRead},
+	}
+	for _, tt := range tests {
+		if got := endpointGroupOf(tt.method, tt.path); got != tt.want {
+			t.Errorf("endpointGroupOf(%s, %s) = %s, want %s", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLimiterRate(t *testing.T) {
+	limiter := NewLimiter(map[EndpointGroup]RateLimit{EndpointGroupRead: {Rate: 100, Burst: 1}}, 0)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, _, err := limiter.Wait(context.Background(), EndpointGroupRead)
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("3 calls at 100/s with burst 1 took %s, want at least 20ms", elapsed)
+	}
+	stats := limiter.Stats()[EndpointGroupRead]
+	if stats.Calls != 3 || stats.Delayed != 2 || stats.MaxWait <= 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+	// Other groups are not limited
+	if _, waited, _ := limiter.Wait(context.Background(), EndpointGroupOrder); waited >= time.Millisecond {
+		t.Errorf("unlimited group waited %s", waited)
+	}
+}
+
+func TestLimiterCancel(t *testing.T) {
+	limiter := NewLimiter(map[EndpointGroup]RateLimit{EndpointGroupRead: {Rate: 0.1, Burst: 1}}, 0)
+	if _, _, err := limiter.Wait(context.Background(), EndpointGroupRead); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := limiter.Wait(ctx, EndpointGroupRead); err != context.DeadlineExceeded {
+		t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestLimiterCancelInFlightRefunds(t *testing.T) {
+	limiter := NewLimiter(map[EndpointGroup]RateLimit{EndpointGroupRead: {Rate: 0.1, Burst: 2}}, 1)
+	release, _, err := limiter.Wait(context.Background(), EndpointGroupRead)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The second token is taken but the in flight slot is busy until the context ends
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err = limiter.Wait(ctx, EndpointGroupRead); err != context.DeadlineExceeded {
+		t.Fatalf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	release()
+	if _, waited, err := limiter.Wait(context.Background(), EndpointGroupRead); err != nil || waited >= time.Second {
+		t.Errorf("Wait() after a canceled call waited %s, %v, want the refunded token", waited, err)
+	}
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	var current, peak int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"code":200,"message":"success","successful":true,"data":[]}`))
+	}))
+	client.Limiter = NewLimiter(nil, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetCoinInfo("BTC", ""); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if peak > 2 {
+		t.Errorf("peak in flight = %d, want at most 2", peak)
+	}
+}
@@ -0,0 +1,238 @@
+package cactus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrUnknownChain is returned by Registry lookups of a chain cactus does not list
+var ErrUnknownChain = errors.New("cactus: unknown chain")
+
+// DefaultRegistryTTL is how long coin and chain metadata is served before it is refreshed
+const DefaultRegistryTTL = time.Hour
+
+// DefaultRegistryRefreshTimeout bounds a background refresh, which outlives the lookup starting it
+const DefaultRegistryRefreshTimeout = time.Minute
+
+// Registry caches the coin-infos and chain-infos of cactus and answers metadata lookups
+// The first lookup loads both lists. Once they are older than TTL, lookups keep answering from the
+// cached lists while a single background refresh replaces them. A failed refresh keeps the old lists.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	client         *Cactus
+	ttl            time.Duration
+	refreshTimeout time.Duration
+
+	loadMu     sync.Mutex // serializes loads
+	mu         sync.RWMutex
+	coins      map[constants.CactusToken]CoinInfo
+	chains     map[constants.ChainName]ChainInfo
+	tokens     map[constants.ChainName][]constants.CactusToken
+	loaded     time.Time
+	refreshing bool
+}
+
+// NewRegistry creates a registry loading metadata with client, ttl <= 0 uses DefaultRegistryTTL
+func NewRegistry(client *Cactus, ttl time.Duration) *Registry {
+	if ttl <= 0 {
+		ttl = DefaultRegistryTTL
+	}
+	return &Registry{client: client, ttl: ttl, refreshTimeout: DefaultRegistryRefreshTimeout}
+}
+
+// Registry returns the registry shared by every helper of this client, created on first use
+func (c *Cactus) Registry() *Registry {
+	c.registryOnce.Do(func() {
+		c.registry = NewRegistry(c, DefaultRegistryTTL)
+	})
+	return c.registry
+}
+
+// Refresh loads the coin and chain lists now
+func (r *Registry) Refresh(ctx context.Context) error {
+	r.loadMu.Lock()
+	defer r.loadMu.Unlock()
+	return r.load(ctx)
+}
+
+func (r *Registry) load(ctx context.Context) error {
+	coinResp, err := r.client.GetCoinInfoWithContext(ctx, "", "")
+	if err != nil {
+		return err
+	}
+	chainResp, err := r.client.GetChainInfoWithContext(ctx, "", "")
+	if err != nil {
+		return err
+	}
+	coins := make(map[constants.CactusToken]CoinInfo, len(coinResp.Data))
+	tokens := map[constants.ChainName][]constants.CactusToken{}
+	for _, coin := range coinResp.Data {
+		token := constants.CactusToken(coin.CactusSymbol)
+		coins[token] = coin
+		chain := coinChain(coin)
+		tokens[chain] = append(tokens[chain], token)
+	}
+	for _, list := range tokens {
+		slices.Sort(list)
+	}
+	chains := make(map[constants.ChainName]ChainInfo, len(chainResp.Data))
+	for _, chain := range chainResp.Data {
+		chains[constants.ChainName(chain.Chain)] = chain
+	}
+	r.mu.Lock()
+	r.coins, r.chains, r.tokens = coins, chains, tokens
+	r.loaded = r.client.clock()
+	r.mu.Unlock()
+	return nil
+}
+
+// coinChain is the cactus chain a coin lives on
+func coinChain(coin CoinInfo) constants.ChainName {
+	if coin.CactusChain != "" {
+		return constants.ChainName(coin.CactusChain)
+	}
+	return constants.ChainName(coin.Chain)
+}
+
+// ensure loads the lists on first use and starts a background refresh once they expired
+func (r *Registry) ensure(ctx context.Context) error {
+	r.mu.Lock()
+	loaded, stale := !r.loaded.IsZero(), r.client.clock().Sub(r.loaded) >= r.ttl
+	startRefresh := loaded && stale && !r.refreshing
+	if startRefresh {
+		r.refreshing = true
+	}
+	r.mu.Unlock()
+	if startRefresh {
+		go r.backgroundRefresh(context.WithoutCancel(ctx))
+	}
+	if loaded {
+		return nil
+	}
+	r.loadMu.Lock()
+	defer r.loadMu.Unlock()
+	r.mu.RLock()
+	loaded = !r.loaded.IsZero()
+	r.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+	return r.load(ctx)
+}
+
+func (r *Registry) backgroundRefresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, r.refreshTimeout)
+	defer cancel()
+	err := r.Refresh(ctx)
+	r.mu.Lock()
+	r.refreshing = false
+	r.mu.Unlock()
+	if err != nil {
+		r.client.log(ctx, slog.LevelWarn, "cactus registry refresh failed", slog.String("error", err.Error()))
+	}
+}
+
+// Coin returns the coin info of token
+func (r *Registry) Coin(ctx context.Context, token constants.CactusToken) (CoinInfo, error) {
+	if err := r.ensure(ctx); err != nil {
+		return CoinInfo{}, err
+	}
+	r.mu.RLock()
+	coin, ok := r.coins[token]
+	r.mu.RUnlock()
+	if !ok {
+		return CoinInfo{}, fmt.Errorf("%w %s", ErrUnknownToken, token)
+	}
+	return coin, nil
+}
+
+// Chain returns the chain info of chain
+func (r *Registry) Chain(ctx context.Context, chain constants.ChainName) (ChainInfo, error) {
+	if err := r.ensure(ctx); err != nil {
+		return ChainInfo{}, err
+	}
+	r.mu.RLock()
+	info, ok := r.chains[chain]
+	r.mu.RUnlock()
+	if !ok {
+		return ChainInfo{}, fmt.Errorf("%w %s", ErrUnknownChain, chain)
+	}
+	return info, nil
+}
+
+// TokenChain returns the chain token lives on
+func (r *Registry) TokenChain(ctx context.Context, token constants.CactusToken) (constants.ChainName, error) {
+	coin, err := r.Coin(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return coinChain(coin), nil
+}
+
+// ContractAddress returns the contract address of token, empty for the main coin of a chain
+func (r *Registry) ContractAddress(ctx context.Context, token constants.CactusToken) (string, error) {
+	coin, err := r.Coin(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return coin.ContractAddress, nil
+}
+
+// Decimals returns the number of decimals of token
+func (r *Registry) Decimals(ctx context.Context, token constants.CactusToken) (int, error) {
+	coin, err := r.Coin(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+	return parseDecimals(coin)
+}
+
+// Confirmations returns how many blocks a deposit of token needs to be confirmed
+func (r *Registry) Confirmations(ctx context.Context, token constants.CactusToken) (int, error) {
+	coin, err := r.Coin(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+	confirmations, err := strconv.Atoi(coin.ConfirmBlockNumber)
+	if err != nil {
+		return 0, fmt.Errorf("cactus: invalid confirm block number %q for %s", coin.ConfirmBlockNumber, token)
+	}
+	return confirmations, nil
+}
+
+// IsEVM reports whether chain is an EVM chain
+func (r *Registry) IsEVM(ctx context.Context, chain constants.ChainName) (bool, error) {
+	info, err := r.Chain(ctx, chain)
+	return info.EvmChain, err
+}
+
+// SupportsEIP1559 reports whether chain accepts EIP-1559 fees
+func (r *Registry) SupportsEIP1559(ctx context.Context, chain constants.ChainName) (bool, error) {
+	info, err := r.Chain(ctx, chain)
+	return info.SupportEip1559, err
+}
+
+// Tokens returns the tokens on chain, sorted
+func (r *Registry) Tokens(ctx context.Context, chain constants.ChainName) ([]constants.CactusToken, error) {
+	if err := r.ensure(ctx); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if _, ok := r.chains[chain]; !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownChain, chain)
+	}
+	return slices.Clone(r.tokens[chain]), nil
+}
+
+// Converter returns a converter taking decimals from the registry
+func (r *Registry) Converter() *Converter {
+	return &Converter{lookup: r.Decimals}
+}
@@ -0,0 +1,155 @@
+package cactus
+
+import (
+	"context"
+	"errors"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"net/http"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	var coinCalls, chainCalls atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GetCoinInfoUrl:
+			coinCalls.Add(1)
+			w.Write([]byte(`{"code":200,"successful":true,"data":[
+				{"cactus_symbol":"USDT_ETH","chain":"ETH","cactus_chain":"ETH","decimals":"6","contract_address":"0xdac17f958d2ee523a2206206994597c13d831ec7","confirm_block_number":"12"},
+				{"cactus_symbol":"ETH","chain":"ETH","cactus_chain":"ETH","decimals":"18","confirm_block_number":"12"},
+				{"cactus_symbol":"BTC","chain":"BTC","cactus_chain":"BTC","decimals":"8","confirm_block_number":"2"}]}`))
+		case GetChainInfoUrl:
+			chainCalls.Add(1)
+			w.Write([]byte(`{"code":200,"successful":true,"data":[
+				{"chain":"ETH","evm_chain":true,"support_eip1559":true},
+				{"chain":"BTC"}]}`))
+		}
+	}))
+	var mu sync.Mutex
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	client.Clock = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	registry := client.Registry()
+	ctx := context.Background()
+
+	chain, err := registry.TokenChain(ctx, "USDT_ETH")
+	if err != nil || chain != constants.ChainNameETH {
+		t.Fatalf("TokenChain() = %s, %v", chain, err)
+	}
+	if decimals, err := registry.Decimals(ctx, "USDT_ETH"); err != nil || decimals != 6 {
+		t.Errorf("Decimals() = %d, %v", decimals, err)
+	}
+	if confirmations, err := registry.Confirmations(ctx, "BTC"); err != nil || confirmations != 2 {
+		t.Errorf("Confirmations() = %d, %v", confirmations, err)
+	}
+	if address, err := registry.ContractAddress(ctx, "USDT_ETH"); err != nil || address != "0xdac17f958d2ee523a2206206994597c13d831ec7" {
+		t.Errorf("ContractAddress() = %s, %v", address, err)
+	}
+	if evm, err := registry.IsEVM(ctx, "ETH"); err != nil || !evm {
+		t.Errorf("IsEVM(ETH) = %v, %v", evm, err)
+	}
+	if eip1559, err := registry.SupportsEIP1559(ctx, "BTC"); err != nil || eip1559 {
+		t.Errorf("SupportsEIP1559(BTC) = %v, %v", eip1559, err)
+	}
+	if tokens, err := registry.Tokens(ctx, "ETH"); err != nil || !slices.Equal(tokens, []constants.CactusToken{"ETH", "USDT_ETH"}) {
+		t.Errorf("Tokens(ETH) = %v, %v", tokens, err)
+	}
+	if _, err = registry.Coin(ctx, "NOPE"); !errors.Is(err, ErrUnknownToken) {
+		t.Errorf("Coin(NOPE) error = %v, want ErrUnknownToken", err)
+	}
+	if _, err = registry.Tokens(ctx, "NOPE"); !errors.Is(err, ErrUnknownChain) {
+		t.Errorf("Tokens(NOPE) error = %v, want ErrUnknownChain", err)
+	}
+	if amount, err := registry.Converter().Parse(ctx, "ETH", "0.5"); err != nil || amount.String() != "500000000000000000" {
+		t.Errorf("Converter().Parse() = %s, %v", amount, err)
+	}
+	if coinCalls.Load() != 1 || chainCalls.Load() != 1 {
+		t.Fatalf("loaded coins %d and chains %d times, want once", coinCalls.Load(), chainCalls.Load())
+	}
+
+	// Expired lists are still served while they are refreshed in the background
+	mu.Lock()
+	now = now.Add(DefaultRegistryTTL)
+	mu.Unlock()
+	if _, err = registry.Coin(ctx, "BTC"); err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); chainCalls.Load() != 2; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("no background refresh after the ttl expired")
+		}
+	}
+	if _, err = registry.Coin(ctx, "BTC"); err != nil || coinCalls.Load() != 2 {
+		t.Errorf("Coin() error = %v after %d loads, want no further load", err, coinCalls.Load())
+	}
+}
+
+func TestRegistryConverterFollowsRefresh(t *testing.T) {
+	var decimals atomic.Value
+	decimals.Store("6")
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := `[]`
+		if r.URL.Path == GetCoinInfoUrl {
+			data = `[{"cactus_symbol":"USDT_ETH","chain":"ETH","decimals":"` + decimals.Load().(string) + `"}]`
+		}
+		w.Write([]byte(`{"code":200,"successful":true,"data":` + data + `}`))
+	}))
+	ctx := context.Background()
+	converter := client.Registry().Converter()
+	if amount, err := converter.Parse(ctx, "USDT_ETH", "1"); err != nil || amount.String() != "1000000" {
+		t.Fatalf("Parse() = %s, %v", amount, err)
+	}
+	decimals.Store("8")
+	if err := client.Registry().Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if amount, err := converter.Parse(ctx, "USDT_ETH", "1"); err != nil || amount.String() != "100000000" {
+		t.Errorf("Parse() after Refresh() = %s, %v, want the refreshed decimals", amount, err)
+	}
+}
+
+func TestRegistryBackgroundRefreshTimesOut(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == GetCoinInfoUrl && calls.Add(1) > 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"code":200,"successful":true,"data":[{"cactus_symbol":"BTC","chain":"BTC","decimals":"8"}]}`))
+	}))
+	var mu sync.Mutex
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	client.Clock = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	registry := client.Registry()
+	registry.refreshTimeout = 20 * time.Millisecond
+	ctx := context.Background()
+	if _, err := registry.Coin(ctx, "BTC"); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	now = now.Add(DefaultRegistryTTL)
+	mu.Unlock()
+	if _, err := registry.Coin(ctx, "BTC"); err != nil {
+		t.Fatal(err)
+	}
+	// The hung refresh is abandoned, so the next lookup starts another one
+	for deadline := time.Now().Add(5 * time.Second); calls.Load() < 3; time.Sleep(5 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("no new refresh after a hung one, the registry is stuck refreshing")
+		}
+		if _, err := registry.Coin(ctx, "BTC"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package cactus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how failed calls are resent
+// GET calls are retried on transport errors, 5xx and 429 responses.
+// POST calls are never resent blindly, only order creation calls carrying an order_no are retried,
+// and only after GetOrderDetails confirmed the order was not created by the failed attempt.
+// POST, PUT and DELETE requests sent through Do are never resent.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one, values below 2 disable retries
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled on every further retry
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay and any Retry-After value sent by the server
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and exponential backoff from 200ms up to 5s
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// backoff returns the delay before the given retry (1 based), with jitter applied
+func (p *RetryPolicy) backoff(retry int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		if p.MaxDelay > 0 && retryAfter > p.MaxDelay {
+			return p.MaxDelay
+		}
+		return retryAfter
+	}
+	delay := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// Equal jitter: keep half of the delay, randomize the other half
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+func (c *Cactus) maxAttempts() int {
+	if c.Retry == nil || c.Retry.MaxAttempts < 1 {
+		return 1
+	}
+	return c.Retry.MaxAttempts
+}
+
+// withRetry calls do until it succeeds, fails with a non retryable error or attempts are exhausted
+// do must be idempotent.
+func (c *Cactus) withRetry(ctx context.Context, do func() ([]byte, error)) ([]byte, error) {
+	attempts := c.maxAttempts()
+	for attempt := 1; ; attempt++ {
+		resp, err := do()
+		if err == nil || attempt >= attempts || !isRetryable(ctx, err) {
+			return resp, err
+		}
+		if err := c.waitRetry(ctx, attempt, err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// orderLookup fetches an order by number from the endpoint listing the orders of one kind
+// It returns an error matching ErrOrderNotFound when the order does not exist.
+type orderLookup func(ctx context.Context, orderNo string) (*Response[CreatedOrder], error)
+
+// postOrder sends an order creation request, retrying only when the order is known not to exist
+// lookup is consulted before every resend, an order without orderNo is never resent.
+func (c *Cactus) postOrder(ctx context.Context, path string, orderNo string, req any, lookup orderLookup) (*Response[CreatedOrder], error) {
+	// Encode once, a resent order carries exactly the same body
+	body, err := encodeBody(req)
+	if err != nil {
+		return nil, err
+	}
+	attempts := c.maxAttempts()
+	resp, err := c.post(ctx, path, body)
+	for attempt := 1; err != nil && orderNo != "" && attempt < attempts && isRetryable(ctx, err); attempt++ {
+		lastErr := err
+		if err := c.waitRetry(ctx, attempt, lastErr); err != nil {
+			return nil, err
+		}
+		found, lookupErr := lookup(ctx, orderNo)
+		if errors.Is(lookupErr, ErrOrderNotFound) {
+			resp, err = c.post(ctx, path, body)
+			continue
+		}
+		if lookupErr != nil {
+			if !isRetryable(ctx, lookupErr) {
+				// We can't tell whether the order went through, report the original failure
+				return nil, fmt.Errorf("%w (order %s state unknown: %v)", lastErr, orderNo, lookupErr)
+			}
+			// Lookup failed transiently, keep the creation error and look again after the next backoff
+			continue
+		}
+		c.log(ctx, slog.LevelWarn, "cactus order already created, not resending", slog.String("path", c.redactPath(path)), slog.String("order_no", orderNo))
+		// The creation response was lost, answer with the lookup response of the existing order
+		return found, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var created Response[CreatedOrder]
+	if err = json.Unmarshal(resp, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// createdOrder turns an order lookup response into the response of the creation call
+func createdOrder[T any](resp *Response[T], orderNo string) *Response[CreatedOrder] {
+	return &Response[CreatedOrder]{Code: resp.Code, Message: resp.Message, Successful: resp.Successful, Data: CreatedOrder{OrderNo: orderNo}}
+}
+
+func (c *Cactus) waitRetry(ctx context.Context, attempt int, lastErr error) error {
+	var retryAfter time.Duration
+	var apiErr *APIError
+	if errors.As(lastErr, &apiErr) {
+		retryAfter = apiErr.RetryAfter
+	}
+	delay := c.Retry.backoff(attempt, retryAfter)
+	c.log(ctx, slog.LevelWarn, "cactus retrying call", slog.Int("attempt", attempt), slog.Duration("delay", delay), c.errorAttr(lastErr))
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRetryable reports whether err is a transport error, a 5xx or a 429 response
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an http date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
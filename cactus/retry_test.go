@@ -0,0 +1,132 @@
+package cactus
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryClient(t *testing.T, handler http.HandlerFunc) *Cactus {
+	client := newTestClient(t, handler)
+	client.Retry = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+	return client
+}
+
+func TestGetRetriesServerErrors(t *testing.T) {
+	var calls int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":200,"message":"success","successful":true,"data":[]}`))
+	})
+	if _, err := client.GetCoinInfo("BTC", ""); err != nil {
+		t.Fatalf("GetCoinInfo() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("GetCoinInfo() sent %d requests, want 3", calls)
+	}
+}
+
+func TestGetDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_, _ = w.Write([]byte(`{"code":400,"message":"bad request","successful":false}`))
+	})
+	if _, err := client.GetCoinInfo("BTC", ""); err == nil {
+		t.Fatal("GetCoinInfo() error = nil")
+	}
+	if calls != 1 {
+		t.Errorf("GetCoinInfo() sent %d requests, want 1", calls)
+	}
+}
+
+func TestCreateOrderRetry(t *testing.T) {
+	tests := []struct {
+		name        string
+		orderNo     string
+		orderExists bool
+		wantPosts   int32
+		wantLookups int32
+		wantErr     bool
+	}{
+		{"without order no", "", false, 1, 0, true},
+		{"order not created", "o-1", false, 2, 1, false},
+		{"order already created", "o-1", true, 1, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posts, lookups int32
+			client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					atomic.AddInt32(&lookups, 1)
+					if !tt.orderExists {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"code":404,"message":"order not exist","successful":false}`))
+						return
+					}
+					_, _ = w.Write([]byte(`{"code":200,"message":"found","successful":true,"data":{}}`))
+					return
+				}
+				if atomic.AddInt32(&posts, 1) == 1 {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				_, _ = w.Write([]byte(`{"code":200,"message":"success","successful":true,"data":{"OrderNo":"o-1"}}`))
+			})
+			resp, err := client.CreateWithdrawOrder("bid", WithdrawalArgsFeeReq{OrderNo: tt.orderNo, FromWalletCode: "w"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateWithdrawOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && resp.Data.OrderNo != "o-1" {
+				t.Errorf("CreateWithdrawOrder() order = %q, want o-1", resp.Data.OrderNo)
+			}
+			if tt.orderExists && resp.Message != "found" {
+				t.Errorf("CreateWithdrawOrder() message = %q, want the lookup response", resp.Message)
+			}
+			if posts != tt.wantPosts || lookups != tt.wantLookups {
+				t.Errorf("CreateWithdrawOrder() posts = %d, lookups = %d, want %d, %d", posts, lookups, tt.wantPosts, tt.wantLookups)
+			}
+		})
+	}
+}
+
+func TestCreateContractOrderLooksUpWallet(t *testing.T) {
+	var lookups []string
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			lookups = append(lookups, r.URL.Path)
+			_, _ = w.Write([]byte(`{"code":200,"message":"found","successful":true,"data":{"order_no":"o-1"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	resp, err := client.CreateContractOrder("bid", CreateContractOrderReq{OrderNo: "o-1", FromWalletCode: "w"})
+	if err != nil {
+		t.Fatalf("CreateContractOrder() error = %v", err)
+	}
+	if resp.Data.OrderNo != "o-1" || len(lookups) != 1 || lookups[0] != fmt.Sprintf(GetDefiTransactionDetailsUrl, "bid", "w", "o-1") {
+		t.Errorf("CreateContractOrder() = %+v after lookups %v, want the contract order of the wallet", resp, lookups)
+	}
+}
+
+func TestCreateOrderUnknownState(t *testing.T) {
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	_, err := client.CreateContractOrder("bid", CreateContractOrderReq{OrderNo: "o-1"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("CreateContractOrder() error = %v, want the original 502", err)
+	}
+}
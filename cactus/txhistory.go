@@ -1,9 +1,11 @@
 package cactus
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"net/url"
 	"strconv"
 )
 
@@ -13,30 +15,7 @@ const (
 	EditTransactionRemarkUrl       = "/custody/v1/api/projects/%s/wallets/%s/details/%s"
 )
 
-type GetWalletTransactionSummaryResp struct {
-	Code       int         `json:"code"`
-	Message    string      `json:"message"`
-	Successful interface{} `json:"successful"`
-	Data       struct {
-		Offset int `json:"offset"`
-		Limit  int `json:"limit"`
-		List   []struct {
-			WalletCode      string `json:"wallet_code"`
-			WalletType      string `json:"wallet_type"`
-			CoinName        string `json:"coin_name"`
-			OrderNo         string `json:"order_no"`
-			BlockHeight     int    `json:"block_height"`
-			TxId            string `json:"tx_id"`
-			TxType          string `json:"tx_type"`
-			Amount          int    `json:"amount"`
-			WalletBalance   int    `json:"wallet_balance"`
-			RemarkDetail    string `json:"remark_detail"`
-			TxTimeStamp     int64  `json:"tx_time_stamp"`
-			CreateTimeStamp int64  `json:"create_time_stamp"`
-		} `json:"list"`
-		Total int `json:"total"`
-	} `json:"data"`
-}
+type GetWalletTransactionSummaryResp = Response[Page[WalletTxSummary]]
 
 // GetWalletTransactionHistory gets the wallet transaction history
 // bId: business id
@@ -61,43 +40,50 @@ func (c *Cactus) GetWalletTransactionHistory(
 	startTime int64,
 	endTime int64,
 ) (*GetWalletTransactionSummaryResp, error) {
-	query := map[string]string{}
+	return c.GetWalletTransactionHistoryWithContext(context.Background(), bId, walletCode, coinName, txTypes, addresses, offset, limit, createTimeOrder, startTime, endTime)
+}
+
+// GetWalletTransactionHistoryWithContext is GetWalletTransactionHistory with a caller supplied context.
+func (c *Cactus) GetWalletTransactionHistoryWithContext(
+	ctx context.Context,
+	bId string,
+	walletCode string,
+	coinName constants.CactusToken,
+	txTypes []constants.TxType,
+	addresses []string,
+	offset int,
+	limit int,
+	createTimeOrder constants.OrderType,
+	startTime int64,
+	endTime int64,
+) (*GetWalletTransactionSummaryResp, error) {
+	query := url.Values{}
 	if offset != 0 {
-		query["offset"] = fmt.Sprintf("%d", offset)
+		query.Set("offset", fmt.Sprintf("%d", offset))
 	}
 	if limit != 0 {
-		query["limit"] = fmt.Sprintf("%d", limit)
+		query.Set("limit", fmt.Sprintf("%d", limit))
 	}
 	if coinName != "" {
-		query["coin_name"] = string(coinName)
+		query.Set("coin_name", string(coinName))
 	}
 	if createTimeOrder != constants.OrderTypeNotUsed {
-		query["create_time_order"] = strconv.Itoa(int(createTimeOrder))
+		query.Set("create_time_order", strconv.Itoa(int(createTimeOrder)))
 	}
 	if startTime != 0 {
-		query["start_time"] = fmt.Sprintf("%d", startTime)
+		query.Set("start_time", fmt.Sprintf("%d", startTime))
 	}
 	if endTime != 0 {
-		query["end_time"] = fmt.Sprintf("%d", endTime)
+		query.Set("end_time", fmt.Sprintf("%d", endTime))
 	}
-	if len(txTypes) > 0 {
-		txTypesString := ""
-		for _, txType := range txTypes {
-			txTypesString += string(txType) + ","
-		}
-		txTypesString = txTypesString[:len(txTypesString)-1]
-		query["tx_types"] = txTypesString
+	for _, txType := range txTypes {
+		query.Add("tx_types", string(txType))
 	}
-	if len(addresses) > 0 {
-		addressesString := ""
-		for _, address := range addresses {
-			addressesString += address + ","
-		}
-		addressesString = addressesString[:len(addressesString)-1]
-		query["addresses"] = addressesString
+	for _, address := range addresses {
+		query.Add("addresses", address)
 	}
-	url := fmt.Sprintf(GetWalletTransactionSummaryUrl, bId, walletCode)
-	resp, err := c.get(url, query)
+	path := fmt.Sprintf(GetWalletTransactionSummaryUrl, bId, walletCode)
+	resp, err := c.get(ctx, path, query)
 	if err != nil {
 		return nil, err
 	}
@@ -109,72 +95,9 @@ func (c *Cactus) GetWalletTransactionHistory(
 	return &getWalletTransactionSummaryResp, nil
 }
 
-type GetTransactionDetailsResp struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Data    struct {
-		Offset int `json:"offset"`
-		Limit  int `json:"limit"`
-		List   []struct {
-			Id             int                  `json:"id"`
-			DomainId       string               `json:"domain_id"`
-			DomainName     interface{}          `json:"domain_name"`
-			DomainCode     interface{}          `json:"domain_code"`
-			KycNumber      interface{}          `json:"kyc_number"`
-			ServiceType    interface{}          `json:"service_type"`
-			WalletCode     string               `json:"wallet_code"`
-			WalletType     constants.WalletType `json:"wallet_type"`
-			CoinName       string               `json:"coin_name"`
-			OrderNo        interface{}          `json:"order_no"`
-			BlockHeight    int                  `json:"block_height"`
-			ConfirmRatio   interface{}          `json:"confirm_ratio"`
-			TxId           string               `json:"tx_id"`
-			TxSize         int                  `json:"tx_size"`
-			TxType         constants.TxType     `json:"tx_type"`
-			WithdrawAmount interface{}          `json:"withdraw_amount"`
-			GasPrice       interface{}          `json:"gas_price"`
-			GasLimit       interface{}          `json:"gas_limit"`
-			TxFee          int                  `json:"tx_fee"`
-			TxFeeRate      interface{}          `json:"tx_fee_rate"`
-			TxFeeType      interface{}          `json:"tx_fee_type"`
-			MinerReward    interface{}          `json:"miner_reward"`
-			MinerFee       interface{}          `json:"miner_fee"`
-			DepositAmount  int                  `json:"deposit_amount"`
-			WalletBalance  int                  `json:"wallet_balance"`
-			ExtendedInfo   struct {
-				DomainCoinBalance int64       `json:"domain_coin_balance"`
-				Attachments       interface{} `json:"attachments"`
-			} `json:"extended_info"`
-			TxStatus     string      `json:"tx_status"`
-			RemarkDetail interface{} `json:"remark_detail"`
-			Vins         []struct {
-				Address  string      `json:"address"`
-				Idx      int         `json:"idx"`
-				Tag      interface{} `json:"tag"`
-				Amount   interface{} `json:"amount"`
-				Balance  interface{} `json:"balance"`
-				IsChange int         `json:"is_change"`
-				Desc     interface{} `json:"desc"`
-			} `json:"vins"`
-			Vouts []struct {
-				Address  string      `json:"address"`
-				Idx      int         `json:"idx"`
-				Tag      interface{} `json:"tag"`
-				Amount   int         `json:"amount"`
-				Balance  int         `json:"balance"`
-				IsChange int         `json:"is_change"`
-				Desc     string      `json:"desc"`
-			} `json:"vouts"`
-			TxTimeStamp     int64  `json:"tx_time_stamp"`
-			CreateTimeStamp int64  `json:"create_time_stamp"`
-			Bid             string `json:"bid"`
-		} `json:"list"`
-		Total int `json:"total"`
-	} `json:"data"`
-	Successful bool `json:"successful"`
-}
+type GetTransactionDetailsResp = Response[Page[WalletTxDetail]]
 
-// GetTransactionDetails gets the transaction details
+// GetTransactionDetails gets the transaction details, ListTransactionDetails takes the filters as a TxDetailFilter
 // bId: business id
 // walletCode: wallet code
 // coinName: coin name, optional
@@ -198,52 +121,57 @@ func (c *Cactus) GetTransactionDetails(
 	startTime int64,
 	endTime int64,
 ) (*GetTransactionDetailsResp, error) {
-	query := map[string]string{}
-	if offset != 0 {
-		query["offset"] = fmt.Sprintf("%d", offset)
-	}
-	if limit != 0 {
-		query["limit"] = fmt.Sprintf("%d", limit)
-	}
-	if coinName != "" {
-		query["coin_name"] = string(coinName)
-	}
-	if createTimeOrder != constants.OrderTypeNotUsed {
-		query["create_time_order"] = strconv.Itoa(int(createTimeOrder))
-	}
-	if startTime != 0 {
-		query["start_time"] = fmt.Sprintf("%d", startTime)
-	}
-	if endTime != 0 {
-		query["end_time"] = fmt.Sprintf("%d", endTime)
-	}
-	if len(txTypes) > 0 {
-		txTypesString := ""
-		for _, txType := range txTypes {
-			txTypesString += string(txType) + ","
-		}
-		txTypesString = txTypesString[:len(txTypesString)-1]
-		query["tx_types"] = txTypesString
-	}
-	if len(addresses) > 0 {
-		addressesString := ""
-		for _, address := range addresses {
-			addressesString += address + ","
-		}
-		addressesString = addressesString[:len(addressesString)-1]
-		query["addresses"] = addressesString
-	}
-	if id != "" {
-		query["id"] = id
-	}
-	if txId != "" {
-		query["tx_id"] = txId
-	}
-	if orderNo != "" {
-		query["order_no"] = orderNo
+	return c.GetTransactionDetailsWithContext(context.Background(), bId, walletCode, coinName, txTypes, addresses, id, txId, orderNo, offset, limit, createTimeOrder, startTime, endTime)
+}
+
+// GetTransactionDetailsWithContext is GetTransactionDetails with a caller supplied context.
+func (c *Cactus) GetTransactionDetailsWithContext(
+	ctx context.Context,
+	bId string,
+	walletCode string,
+	coinName constants.CactusToken,
+	txTypes []constants.TxType,
+	addresses []string,
+	id string,
+	txId string,
+	orderNo string,
+	offset int,
+	limit int,
+	createTimeOrder constants.OrderType,
+	startTime int64,
+	endTime int64,
+) (*GetTransactionDetailsResp, error) {
+	return c.ListTransactionDetailsWithContext(ctx, bId, walletCode, TxDetailFilter{
+		CoinName:        coinName,
+		TxTypes:         txTypes,
+		Addresses:       addresses,
+		Id:              id,
+		TxId:            txId,
+		OrderNo:         orderNo,
+		CreateTimeOrder: sortOrderOf(createTimeOrder),
+		Start:           timeOf(startTime),
+		End:             timeOf(endTime),
+	}, offset, limit)
+}
+
+// ListTransactionDetails gets one page of the wallet transactions matching filter
+// bId: business id
+// walletCode: wallet code
+// offset: offset, optional, default 0
+// limit: limit, optional, default 10
+func (c *Cactus) ListTransactionDetails(bId string, walletCode string, filter TxDetailFilter, offset int, limit int) (*GetTransactionDetailsResp, error) {
+	return c.ListTransactionDetailsWithContext(context.Background(), bId, walletCode, filter, offset, limit)
+}
+
+// ListTransactionDetailsWithContext is ListTransactionDetails with a caller supplied context.
+func (c *Cactus) ListTransactionDetailsWithContext(ctx context.Context, bId string, walletCode string, filter TxDetailFilter, offset int, limit int) (*GetTransactionDetailsResp, error) {
+	query, err := filter.values()
+	if err != nil {
+		return nil, err
 	}
-	url := fmt.Sprintf(GetTransactionDetailsUrl, bId, walletCode)
-	resp, err := c.get(url, query)
+	setPage(query, offset, limit)
+	path := fmt.Sprintf(GetTransactionDetailsUrl, bId, walletCode)
+	resp, err := c.get(ctx, path, query)
 	if err != nil {
 		return nil, err
 	}
@@ -255,11 +183,7 @@ func (c *Cactus) GetTransactionDetails(
 	return &getTransactionDetailsResp, nil
 }
 
-type EditTransactionRemarkResp struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	Successful bool   `json:"successful"`
-}
+type EditTransactionRemarkResp = Response[NoData]
 
 // EditTransactionRemark edits the transaction remark
 // bId: business id
@@ -267,11 +191,16 @@ type EditTransactionRemarkResp struct {
 // id: wallet detail item id
 // remark: remark
 func (c *Cactus) EditTransactionRemark(bId string, walletCode string, id string, remark string) (*EditTransactionRemarkResp, error) {
+	return c.EditTransactionRemarkWithContext(context.Background(), bId, walletCode, id, remark)
+}
+
+// EditTransactionRemarkWithContext is EditTransactionRemark with a caller supplied context.
+func (c *Cactus) EditTransactionRemarkWithContext(ctx context.Context, bId string, walletCode string, id string, remark string) (*EditTransactionRemarkResp, error) {
 	url := fmt.Sprintf(EditTransactionRemarkUrl, bId, walletCode, id)
-	req := map[string]interface{}{
-		"remark": remark,
-	}
-	resp, err := c.post(url, req)
+	req := struct {
+		Remark string `json:"remark"`
+	}{remark}
+	resp, err := c.post(ctx, url, req)
 	if err != nil {
 		return nil, err
 	}
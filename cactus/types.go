@@ -0,0 +1,343 @@
+package cactus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Response is the envelope every cactus endpoint replies with
+// Data holds the endpoint specific payload.
+type Response[T any] struct {
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+	Successful bool   `json:"successful"`
+	Data       T      `json:"data"`
+}
+
+// Page is one page of a list endpoint
+type Page[T any] struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	Total  int `json:"total"`
+	List   []T `json:"list"`
+}
+
+// NoData is the payload of endpoints that only report success
+type NoData = json.RawMessage
+
+// Number is a numeric field cactus sends as a json number, a numeric string or null
+// It keeps the decimal text as sent, "" when the field was null or an empty string.
+type Number string
+
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// UnmarshalJSON accepts a number, a string holding a number, "" and null
+func (n *Number) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	if s == "null" {
+		*n = ""
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		s = strings.TrimSpace(s)
+	}
+	if s != "" && !jsonNumber.MatchString(s) {
+		return fmt.Errorf("cactus: invalid number %q", s)
+	}
+	*n = Number(s)
+	return nil
+}
+
+// MarshalJSON writes a json number, null for ""
+func (n Number) MarshalJSON() ([]byte, error) {
+	if n == "" {
+		return []byte("null"), nil
+	}
+	return []byte(n), nil
+}
+
+// Float64 returns the number as a float, 0 for ""
+func (n Number) Float64() (float64, error) {
+	if n == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(string(n), 64)
+}
+
+// Int64 returns the number as an integer, 0 for "", an error if it has a fraction or overflows
+func (n Number) Int64() (int64, error) {
+	if n == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(string(n), 10, 64)
+}
+
+// Wallet is a wallet and its balance of one coin
+type Wallet struct {
+	DomainId              string                `json:"domain_id"`
+	BId                   string                `json:"b_id"`
+	WalletCode            string                `json:"wallet_code"`
+	WalletName            string                `json:"wallet_name"`
+	CoinName              string                `json:"coin_name"`
+	ContractAddress       interface{}           `json:"contract_address"`
+	WalletType            constants.WalletType  `json:"wallet_type"`
+	StorageType           constants.StorageType `json:"storage_type"`
+	AvailableAmount       Amount                `json:"available_amount"`
+	FreezeAmount          Amount                `json:"freeze_amount"`
+	TotalAmount           Amount                `json:"total_amount"`
+	UsdTotalMarket        float64               `json:"usd_total_market"`
+	CnyTotalMarket        float64               `json:"cny_total_market"`
+	CoinStatus            string                `json:"coin_status"`
+	ChineseReasonOfStatus string                `json:"chinese_reason_of_status"`
+	EnglishReasonOfStatus string                `json:"english_reason_of_status"`
+	NormalAddressLimit    int                   `json:"normal_address_limit"`
+	NormalAddressNum      int                   `json:"normal_address_num"`
+	CreateTime            int64                 `json:"create_time"`
+}
+
+// Address is a wallet address and its balance of one coin
+type Address struct {
+	Address          string      `json:"address"`
+	AddressStorage   string      `json:"address_storage"`
+	AddressType      string      `json:"address_type"`
+	AvailableAmount  Amount      `json:"available_amount"`
+	CoinName         string      `json:"coin_name"`
+	BchAddressFormat interface{} `json:"bch_address_format"`
+	BId              string      `json:"b_id"`
+	Description      string      `json:"description"`
+	DomainId         string      `json:"domain_id"`
+	FreezeAmount     Amount      `json:"freeze_amount"`
+	TotalAmount      Amount      `json:"total_amount"`
+	WalletCode       string      `json:"wallet_code"`
+	WalletType       string      `json:"wallet_type"`
+}
+
+// Order is a withdrawal order
+// The order list sets TimeStamp and OriginalAmount, the order details set Timestamp, WalletType, Value and MinerFee.
+type Order struct {
+	DomainId                   string             `json:"domain_id"`
+	BusinessName               string             `json:"business_name"`
+	CoinName                   string             `json:"coin_name"`
+	WalletType                 string             `json:"wallet_type"`
+	StorageType                string             `json:"storage_type"`
+	WalletName                 string             `json:"wallet_name"`
+	WalletCode                 string             `json:"wallet_code"`
+	TimeStamp                  int64              `json:"time_stamp"`
+	Timestamp                  int64              `json:"timestamp"`
+	OrderNo                    string             `json:"order_no"`
+	Applicant                  string             `json:"applicant"`
+	Amount                     Amount             `json:"amount"`
+	OriginalAmount             Amount             `json:"original_amount"`
+	ExchangeRate               float64            `json:"exchange_rate"`
+	Value                      interface{}        `json:"value"`
+	MinerFeeRate               Number             `json:"miner_fee_rate"`
+	Description                string             `json:"description"`
+	Status                     string             `json:"status"`
+	InnerStatus                string             `json:"inner_status"`
+	MinerFee                   Amount             `json:"miner_fee"`
+	FromAddress                string             `json:"from_address"`
+	GasPrice                   Number             `json:"gas_price"`
+	GasLimit                   Number             `json:"gas_limit"`
+	OrderDestAddressInfoVoList []OrderDestination `json:"order_dest_address_info_vo_list"`
+	Bid                        string             `json:"bid"`
+}
+
+// OrderDestination is one destination of an order
+type OrderDestination struct {
+	DestAddress   string `json:"dest_address"`
+	MemoType      string `json:"memo_type"`
+	Memo          string `json:"memo"`
+	OriginBalance Amount `json:"origin_balance"`
+	Balance       Amount `json:"balance"`
+	Remark        string `json:"remark"`
+}
+
+// OrderDetails is an order with the transactions it produced
+type OrderDetails struct {
+	OrderWalletInfo           Order         `json:"order_wallet_info"`
+	TxInfoModels              []TxInfo      `json:"tx_info_models"`
+	ConsolidationTxInfoModels []TxInfo      `json:"consolidation_tx_info_models"`
+	MinerFeeTxInfoModels      []TxInfo      `json:"miner_fee_tx_info_models"`
+	PartialFailed             []interface{} `json:"partial_failed"`
+	PartialSuccess            []interface{} `json:"partial_success"`
+}
+
+// TxInfo is an on chain transaction sent for an order
+type TxInfo struct {
+	TxType      string `json:"tx_type"`
+	BlockHeight int    `json:"block_height"`
+	TxSize      int    `json:"tx_size"`
+	TxHash      string `json:"tx_hash"`
+	GasPrice    Number `json:"gas_price"`
+	GasLimit    Number `json:"gas_limit"`
+	MinerFee    Amount `json:"miner_fee"`
+}
+
+// CreatedOrder is returned by the endpoints creating an order
+type CreatedOrder struct {
+	OrderNo string `json:"OrderNo"`
+}
+
+// FeeRateRange is the custom fee rate range of a coin
+type FeeRateRange struct {
+	MaxFeeRate int `json:"maxFeeRate"`
+	MinFeeRate int `json:"minFeeRate"`
+}
+
+// WalletTxSummary is one entry of the wallet transaction history
+type WalletTxSummary struct {
+	WalletCode      string `json:"wallet_code"`
+	WalletType      string `json:"wallet_type"`
+	CoinName        string `json:"coin_name"`
+	OrderNo         string `json:"order_no"`
+	BlockHeight     int    `json:"block_height"`
+	TxId            string `json:"tx_id"`
+	TxType          string `json:"tx_type"`
+	Amount          Amount `json:"amount"`
+	WalletBalance   Amount `json:"wallet_balance"`
+	RemarkDetail    string `json:"remark_detail"`
+	TxTimeStamp     int64  `json:"tx_time_stamp"`
+	CreateTimeStamp int64  `json:"create_time_stamp"`
+}
+
+// WalletTxDetail is a wallet transaction with its inputs and outputs
+type WalletTxDetail struct {
+	Id              int                  `json:"id"`
+	DomainId        string               `json:"domain_id"`
+	DomainName      interface{}          `json:"domain_name"`
+	DomainCode      interface{}          `json:"domain_code"`
+	KycNumber       interface{}          `json:"kyc_number"`
+	ServiceType     interface{}          `json:"service_type"`
+	WalletCode      string               `json:"wallet_code"`
+	WalletType      constants.WalletType `json:"wallet_type"`
+	CoinName        string               `json:"coin_name"`
+	OrderNo         interface{}          `json:"order_no"`
+	BlockHeight     int                  `json:"block_height"`
+	ConfirmRatio    interface{}          `json:"confirm_ratio"`
+	TxId            string               `json:"tx_id"`
+	TxSize          int                  `json:"tx_size"`
+	TxType          constants.TxType     `json:"tx_type"`
+	WithdrawAmount  Amount               `json:"withdraw_amount"`
+	GasPrice        Number               `json:"gas_price"`
+	GasLimit        Number               `json:"gas_limit"`
+	TxFee           Amount               `json:"tx_fee"`
+	TxFeeRate       Number               `json:"tx_fee_rate"`
+	TxFeeType       interface{}          `json:"tx_fee_type"`
+	MinerReward     Amount               `json:"miner_reward"`
+	MinerFee        Amount               `json:"miner_fee"`
+	DepositAmount   Amount               `json:"deposit_amount"`
+	WalletBalance   Amount               `json:"wallet_balance"`
+	ExtendedInfo    TxExtendedInfo       `json:"extended_info"`
+	TxStatus        string               `json:"tx_status"`
+	RemarkDetail    interface{}          `json:"remark_detail"`
+	Vins            []TxPort             `json:"vins"`
+	Vouts           []TxPort             `json:"vouts"`
+	TxTimeStamp     int64                `json:"tx_time_stamp"`
+	CreateTimeStamp int64                `json:"create_time_stamp"`
+	Bid             string               `json:"bid"`
+}
+
+// TxExtendedInfo is the extended info of a wallet transaction
+type TxExtendedInfo struct {
+	DomainCoinBalance Amount      `json:"domain_coin_balance"`
+	Attachments       interface{} `json:"attachments"`
+}
+
+// TxPort is an input or output of a wallet transaction
+type TxPort struct {
+	Address  string      `json:"address"`
+	Idx      int         `json:"idx"`
+	Tag      interface{} `json:"tag"`
+	Amount   Amount      `json:"amount"`
+	Balance  Amount      `json:"balance"`
+	IsChange int         `json:"is_change"`
+	Desc     interface{} `json:"desc"`
+}
+
+// CoinInfo is a coin supported by cactus
+type CoinInfo struct {
+	CactusSymbol       string `json:"cactus_symbol"`
+	Symbol             string `json:"symbol"`
+	Chain              string `json:"chain"`
+	CactusChain        string `json:"cactus_chain"`
+	Decimals           string `json:"decimals"`
+	ContractAddress    string `json:"contract_address"`
+	DepositBlockNumber string `json:"deposit_block_number"`
+	ConfirmBlockNumber string `json:"confirm_block_number"`
+}
+
+// ChainInfo is a chain supported by cactus
+type ChainInfo struct {
+	Chain              string `json:"chain"`
+	FullName           string `json:"full_name"`
+	MainCoin           string `json:"main_coin"`
+	EvmChain           bool   `json:"evm_chain"`
+	SupportEip1559     bool   `json:"support_eip1559"`
+	ConfirmBlockNumber int    `json:"confirm_block_number"`
+	MinerBlockNumber   int    `json:"miner_block_number"`
+}
+
+// ContractOrder is a defi contract call or signature order
+// Only the contract order details set ContractFunction.
+type ContractOrder struct {
+	TimeStamp        int64           `json:"time_stamp"`
+	MinerFee         Amount          `json:"miner_fee"`
+	OrderNo          string          `json:"order_no"`
+	ContractAddress  string          `json:"contract_address"`
+	ContractFunction string          `json:"contract_function"`
+	GasPrice         Amount          `json:"gas_price"`
+	GasLimit         int             `json:"gas_limit"`
+	ContractData     string          `json:"contract_data"`
+	Applicant        string          `json:"applicant"`
+	Status           string          `json:"status"`
+	Amount           Amount          `json:"amount"`
+	Description      interface{}     `json:"description"`
+	TxId             string          `json:"tx_id"`
+	DepositTrans     []TokenTransfer `json:"deposit_trans"`
+	WithdrawTrans    []TokenTransfer `json:"withdraw_trans"`
+}
+
+// TokenTransfer is a token moved in or out of a wallet by a contract order
+type TokenTransfer struct {
+	Amount   Amount `json:"amount"`
+	CoinName string `json:"coin_name"`
+}
+
+// AssetHistory is the notional value of the assets over time
+type AssetHistory struct {
+	HistoryAssetResult []AssetSnapshot `json:"history_asset_result"`
+}
+
+// AssetSnapshot is the notional value of the assets at one time
+type AssetSnapshot struct {
+	CreateTime     string `json:"create_time"`
+	MarketValue    string `json:"market_value"`
+	MarketValueCny string `json:"market_value_cny"`
+}
+
+// AssetValue is the current notional value of the assets
+type AssetValue struct {
+	ColdMarketValue     float64     `json:"cold_market_value"`
+	ColdMarketValueCny  float64     `json:"cold_market_value_cny"`
+	HotMarketValue      float64     `json:"hot_market_value"`
+	HotMarketValueCny   float64     `json:"hot_market_value_cny"`
+	TotalMarketValue    float64     `json:"total_market_value"`
+	TotalMarketValueCny float64     `json:"total_market_value_cny"`
+	Coins               []CoinValue `json:"coins"`
+}
+
+// CoinValue is the notional value of one coin
+type CoinValue struct {
+	CoinName  string  `json:"coin_name"`
+	Amount    Amount  `json:"amount"`
+	Value     float64 `json:"value"`
+	ValueCny  float64 `json:"value_cny"`
+	StoreType string  `json:"store_type"`
+}
@@ -0,0 +1,86 @@
+package cactus
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSharedWalletAndOrderTypes(t *testing.T) {
+	const wallet = `{"wallet_code":"w1","coin_name":"ETH","wallet_type":"MPC","total_amount":42}`
+	const order = `{"order_no":"o1","status":"COMPLETED","gas_price":3,"gas_limit":null,"miner_fee_rate":"","order_dest_address_info_vo_list":[{"dest_address":"0xabc","balance":7}]}`
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := wallet
+		switch {
+		case r.URL.Path == GetWalletListUrl:
+			data = `{"offset":0,"limit":10,"total":1,"list":[` + wallet + `]}`
+		case strings.HasSuffix(r.URL.Path, "/orders"):
+			data = `{"total":1,"list":[` + order + `]}`
+		case strings.Contains(r.URL.Path, "/orders/"):
+			data = `{"order_wallet_info":` + order + `,"tx_info_models":[{"tx_hash":"0xdef","gas_price":"12.5","gas_limit":"21000"}]}`
+		}
+		w.Write([]byte(`{"code":200,"successful":true,"data":` + data + `}`))
+	}))
+
+	single, err := client.GetSingleWalletInfo("b", "w1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := client.GetWalletList("", "", false, nil, "", "", "", "", "", 0, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range []Wallet{single.Data, list.Data.List[0]} {
+		if w.WalletCode != "w1" || w.TotalAmount.String() != "42" {
+			t.Errorf("wallet = %+v", w)
+		}
+	}
+
+	orders, err := client.GetFilteredOrder("b", nil, nil, nil, nil, nil, "", 0, 0, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	details, err := client.GetOrderDetails("b", "o1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range []Order{orders.Data.List[0], details.Data.OrderWalletInfo} {
+		if o.OrderNo != "o1" || o.GasPrice != "3" || o.GasLimit != "" || o.OrderDestAddressInfoVoList[0].Balance.String() != "7" {
+			t.Errorf("order = %+v", o)
+		}
+	}
+	if tx := details.Data.TxInfoModels[0]; tx.TxHash != "0xdef" || tx.GasPrice != "12.5" || tx.GasLimit != "21000" {
+		t.Errorf("tx info = %+v", details.Data.TxInfoModels[0])
+	}
+}
+
+func TestNumber(t *testing.T) {
+	for _, bad := range []string{`"abc"`, `"1/2"`, `"0x10"`, `true`} {
+		var n Number
+		if err := n.UnmarshalJSON([]byte(bad)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) = %q, want an error", bad, n)
+		}
+	}
+	var n Number
+	if err := n.UnmarshalJSON([]byte(`" 1.5e3 "`)); err != nil {
+		t.Fatal(err)
+	}
+	if f, err := n.Float64(); err != nil || f != 1500 {
+		t.Errorf("Float64() = %v, %v, want 1500", f, err)
+	}
+	if _, err := n.Int64(); err == nil {
+		t.Error("Int64() of 1.5e3 error = nil")
+	}
+	if out, _ := Number("").MarshalJSON(); string(out) != "null" {
+		t.Errorf("MarshalJSON() of empty = %s, want null", out)
+	}
+
+	var tx WalletTxDetail
+	if err := json.Unmarshal([]byte(`{"gas_price":"20","gas_limit":21000,"tx_fee_rate":null}`), &tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.GasPrice != "20" || tx.GasLimit != "21000" || tx.TxFeeRate != "" {
+		t.Errorf("wallet tx detail = %+v", tx)
+	}
+}
@@ -0,0 +1,160 @@
+package cactus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Errors returned by ParseUnits and the Converter
+var (
+	ErrInvalidAmount   = errors.New("cactus: invalid amount")
+	ErrTooPrecise      = errors.New("cactus: amount has more decimals than the token supports")
+	ErrUnknownToken    = errors.New("cactus: unknown token")
+	ErrInvalidDecimals = errors.New("cactus: invalid token decimals")
+)
+
+// ParseUnits converts a decimal string like "1.25" into base units of a token with the given decimals
+// A leading sign is allowed, exponents and thousands separators are not.
+func ParseUnits(s string, decimals int) (Amount, error) {
+	if decimals < 0 {
+		return Amount{}, ErrInvalidDecimals
+	}
+	digits := strings.TrimSpace(s)
+	negative := false
+	if digits != "" && (digits[0] == '-' || digits[0] == '+') {
+		negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+	whole, frac, _ := strings.Cut(digits, ".")
+	if whole == "" && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return Amount{}, fmt.Errorf("%w %q", ErrInvalidAmount, s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	// Trailing zeros never add precision
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > decimals {
+		return Amount{}, fmt.Errorf("%w: %q, %d decimals allowed", ErrTooPrecise, s, decimals)
+	}
+	i, _ := new(big.Int).SetString(whole+frac+strings.Repeat("0", decimals-len(frac)), 10)
+	if negative {
+		i.Neg(i)
+	}
+	return Amount{i: i}, nil
+}
+
+// FormatUnits formats base units of a token with the given decimals as a decimal string
+// Trailing zeros of the fraction are dropped, 1250000 with 6 decimals is "1.25".
+func FormatUnits(a Amount, decimals int) string {
+	if decimals <= 0 {
+		return a.String()
+	}
+	digits := new(big.Int).Abs(a.big()).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	whole, frac := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	sign := ""
+	if a.Sign() < 0 {
+		sign = "-"
+	}
+	if frac == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + frac
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Converter converts between display amounts and base units with the decimals GetCoinInfo reports
+// Decimals are fetched on first use of a token and cached for the life of the Converter.
+// Registry.Converter returns one reading the registry on every call, so it follows the registry TTL.
+// A Converter is safe for concurrent use.
+type Converter struct {
+	lookup func(ctx context.Context, token constants.CactusToken) (int, error)
+	cache  bool
+
+	mu       sync.Mutex
+	decimals map[constants.CactusToken]int
+}
+
+// NewConverter creates a converter fetching decimals with client
+func NewConverter(client *Cactus) *Converter {
+	return &Converter{cache: true, lookup: func(ctx context.Context, token constants.CactusToken) (int, error) {
+		resp, err := client.GetCoinInfoWithContext(ctx, string(token), "")
+		if err != nil {
+			return 0, err
+		}
+		for _, coin := range resp.Data {
+			if coin.CactusSymbol == string(token) {
+				return parseDecimals(coin)
+			}
+		}
+		return 0, fmt.Errorf("%w %s", ErrUnknownToken, token)
+	}}
+}
+
+func parseDecimals(coin CoinInfo) (int, error) {
+	decimals, err := strconv.Atoi(coin.Decimals)
+	if err != nil || decimals < 0 {
+		return 0, fmt.Errorf("%w %q for %s", ErrInvalidDecimals, coin.Decimals, coin.CactusSymbol)
+	}
+	return decimals, nil
+}
+
+// Decimals returns the number of decimals of token
+func (v *Converter) Decimals(ctx context.Context, token constants.CactusToken) (int, error) {
+	if !v.cache {
+		return v.lookup(ctx, token)
+	}
+	v.mu.Lock()
+	decimals, ok := v.decimals[token]
+	v.mu.Unlock()
+	if ok {
+		return decimals, nil
+	}
+	decimals, err := v.lookup(ctx, token)
+	if err != nil {
+		return 0, err
+	}
+	v.mu.Lock()
+	if v.decimals == nil {
+		v.decimals = map[constants.CactusToken]int{}
+	}
+	v.decimals[token] = decimals
+	v.mu.Unlock()
+	return decimals, nil
+}
+
+// Parse converts a display amount like "1.25" of token into base units
+// Amounts with more decimals than the token supports are rejected with ErrTooPrecise.
+func (v *Converter) Parse(ctx context.Context, token constants.CactusToken, s string) (Amount, error) {
+	decimals, err := v.Decimals(ctx, token)
+	if err != nil {
+		return Amount{}, err
+	}
+	return ParseUnits(s, decimals)
+}
+
+// Format converts base units of token into a display amount
+func (v *Converter) Format(ctx context.Context, token constants.CactusToken, a Amount) (string, error) {
+	decimals, err := v.Decimals(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return FormatUnits(a, decimals), nil
+}
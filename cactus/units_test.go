@@ -0,0 +1,84 @@
+package cactus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParseAndFormatUnits(t *testing.T) {
+	tests := []struct {
+		in       string
+		decimals int
+		want     string
+		format   string
+		wantErr  error
+	}{
+		{"1.25", 6, "1250000", "1.25", nil},
+		{"1", 18, "1000000000000000000", "1", nil},
+		{"0.000000000000000001", 18, "1", "0.000000000000000001", nil},
+		{"123456789.123456789123456789", 18, "123456789123456789123456789", "123456789.123456789123456789", nil},
+		{"-0.5", 8, "-50000000", "-0.5", nil},
+		{".5", 1, "5", "0.5", nil},
+		{"2.10", 1, "21", "2.1", nil},
+		{"7", 0, "7", "7", nil},
+		{"1.001", 2, "", "", ErrTooPrecise},
+		{"0.1", 0, "", "", ErrTooPrecise},
+		{"1e3", 6, "", "", ErrInvalidAmount},
+		{"1,000", 6, "", "", ErrInvalidAmount},
+		{"1.2.3", 6, "", "", ErrInvalidAmount},
+		{"", 6, "", "", ErrInvalidAmount},
+		{"-", 6, "", "", ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		got, err := ParseUnits(tt.in, tt.decimals)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseUnits(%q, %d) error = %v, want %v", tt.in, tt.decimals, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseUnits(%q, %d) = %s, want %s", tt.in, tt.decimals, got, tt.want)
+		}
+		if formatted := FormatUnits(got, tt.decimals); formatted != tt.format {
+			t.Errorf("FormatUnits(%s, %d) = %q, want %q", got, tt.decimals, formatted, tt.format)
+		}
+	}
+}
+
+func TestConverter(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Query().Get("cactus_symbol") {
+		case "USDT_ETH":
+			w.Write([]byte(`{"code":200,"successful":true,"data":[{"cactus_symbol":"USDT_ETH","decimals":"6"}]}`))
+		default:
+			w.Write([]byte(`{"code":200,"successful":true,"data":[]}`))
+		}
+	}))
+	converter := NewConverter(client)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		amount, err := converter.Parse(ctx, "USDT_ETH", "1.25")
+		if err != nil || amount.String() != "1250000" {
+			t.Fatalf("Parse() = %s, %v", amount, err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("coin info fetched %d times, want 1", calls.Load())
+	}
+	if s, err := converter.Format(ctx, "USDT_ETH", NewAmount(1)); err != nil || s != "0.000001" {
+		t.Errorf("Format() = %q, %v", s, err)
+	}
+	if _, err := converter.Parse(ctx, "USDT_ETH", "0.0000001"); !errors.Is(err, ErrTooPrecise) {
+		t.Errorf("Parse() error = %v, want ErrTooPrecise", err)
+	}
+	if _, err := converter.Parse(ctx, "NOPE", "1"); !errors.Is(err, ErrUnknownToken) {
+		t.Errorf("Parse() error = %v, want ErrUnknownToken", err)
+	}
+}
@@ -1,9 +1,11 @@
 package cactus
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"net/url"
 )
 
 const (
@@ -13,12 +15,7 @@ const (
 	GetWithdrawalRateUrl     = "/custody/v1/api/recommend-fee-rate/list"
 )
 
-type EstimateWithdrawalFeeResp struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	Data       int    `json:"data"`
-	Successful bool   `json:"successful"`
-}
+type EstimateWithdrawalFeeResp = Response[Amount]
 
 type WithdrawalArgsFeeReq struct {
 	FromAddress         string                 `json:"from_address,omitempty"`
@@ -32,7 +29,7 @@ type WithdrawalArgsFeeReq struct {
 }
 
 type DestAddressItem struct {
-	Amount          int                `json:"amount"`
+	Amount          Amount             `json:"amount"`
 	DestAddress     string             `json:"dest_address"`
 	MemoType        string             `json:"memo_type,omitempty"`
 	Memo            constants.MemoType `json:"memo,omitempty"`
@@ -44,18 +41,13 @@ type DestAddressItem struct {
 // bId: business id
 // req: request body
 func (c *Cactus) EstimateWithdrawalFee(bId string, req WithdrawalArgsFeeReq) (*EstimateWithdrawalFeeResp, error) {
+	return c.EstimateWithdrawalFeeWithContext(context.Background(), bId, req)
+}
+
+// EstimateWithdrawalFeeWithContext is EstimateWithdrawalFee with a caller supplied context.
+func (c *Cactus) EstimateWithdrawalFeeWithContext(ctx context.Context, bId string, req WithdrawalArgsFeeReq) (*EstimateWithdrawalFeeResp, error) {
 	path := fmt.Sprintf(EstimateWithdrawalFeeUrl, bId)
-	// serialize req and deserialize to map
-	reqBytes, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-	var reqMap map[string]interface{}
-	err = json.Unmarshal(reqBytes, &reqMap)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.post(path, reqMap)
+	resp, err := c.post(ctx, path, req)
 	if err != nil {
 		return nil, err
 	}
@@ -67,57 +59,40 @@ func (c *Cactus) EstimateWithdrawalFee(bId string, req WithdrawalArgsFeeReq) (*E
 	return &estimateWithdrawalFeeResp, nil
 }
 
-type CreateWithdrawalOrderResp struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	Successful bool   `json:"successful"`
-	Data       struct {
-		OrderNo string `json:"OrderNo"`
-	} `json:"data"`
-}
+type CreateWithdrawalOrderResp = Response[CreatedOrder]
 
 // CreateWithdrawOrder creates the withdrawal order
 // bId: business id
-// req: request body
+// req: request body, set OrderNo to let the client retry safely under Retry
 func (c *Cactus) CreateWithdrawOrder(bId string, req WithdrawalArgsFeeReq) (*CreateWithdrawalOrderResp, error) {
-	path := fmt.Sprintf(CreateWithdrawalOrderUrl, bId)
-	// serialize req and deserialize to map
-	reqBytes, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-	var reqMap map[string]interface{}
-	err = json.Unmarshal(reqBytes, &reqMap)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.post(path, reqMap)
-	if err != nil {
-		return nil, err
-	}
-	var createWithdrawalOrderResp CreateWithdrawalOrderResp
-	err = json.Unmarshal(resp, &createWithdrawalOrderResp)
-	if err != nil {
-		return nil, err
-	}
-	return &createWithdrawalOrderResp, nil
+	return c.CreateWithdrawOrderWithContext(context.Background(), bId, req)
 }
 
-type GetWithdrawalFeeRangeResp struct {
-	Code       int    `json:"code"`
-	Message    string `json:"message"`
-	Successful bool   `json:"successful"`
-	Data       struct {
-		MaxFeeRate int `json:"maxFeeRate"`
-		MinFeeRate int `json:"minFeeRate"`
-	} `json:"data"`
+// CreateWithdrawOrderWithContext is CreateWithdrawOrder with a caller supplied context.
+func (c *Cactus) CreateWithdrawOrderWithContext(ctx context.Context, bId string, req WithdrawalArgsFeeReq) (*CreateWithdrawalOrderResp, error) {
+	path := fmt.Sprintf(CreateWithdrawalOrderUrl, bId)
+	// Only resent when order_no is set and the order does not exist yet
+	return c.postOrder(ctx, path, req.OrderNo, req, func(ctx context.Context, orderNo string) (*Response[CreatedOrder], error) {
+		resp, err := c.GetOrderDetailsWithContext(ctx, bId, orderNo)
+		if err != nil {
+			return nil, err
+		}
+		return createdOrder(resp, orderNo), nil
+	})
 }
 
+type GetWithdrawalFeeRangeResp = Response[FeeRateRange]
+
 func (c *Cactus) GetWithdrawalFeeRange(coinName constants.CactusToken) (*GetWithdrawalFeeRangeResp, error) {
-	params := map[string]string{
-		"coin_name": string(coinName),
+	return c.GetWithdrawalFeeRangeWithContext(context.Background(), coinName)
+}
+
+// GetWithdrawalFeeRangeWithContext is GetWithdrawalFeeRange with a caller supplied context.
+func (c *Cactus) GetWithdrawalFeeRangeWithContext(ctx context.Context, coinName constants.CactusToken) (*GetWithdrawalFeeRangeResp, error) {
+	params := url.Values{
+		"coin_name": {string(coinName)},
 	}
-	resp, err := c.get(GetWithdrawalFeeRangeUrl, params)
+	resp, err := c.get(ctx, GetWithdrawalFeeRangeUrl, params)
 	if err != nil {
 		return nil, err
 	}
@@ -129,18 +104,18 @@ func (c *Cactus) GetWithdrawalFeeRange(coinName constants.CactusToken) (*GetWith
 	return &getWithdrawalFeeRangeResp, nil
 }
 
-type GetWithdrawalRateResp struct {
-	Code       int     `json:"code"`
-	Message    string  `json:"message"`
-	Successful bool    `json:"successful"`
-	Data       []int64 `json:"data"`
-}
+type GetWithdrawalRateResp = Response[[]int64]
 
 func (c *Cactus) GetWithdrawalRate(coinName constants.CactusToken) (*GetWithdrawalRateResp, error) {
-	params := map[string]string{
-		"coin_name": string(coinName),
+	return c.GetWithdrawalRateWithContext(context.Background(), coinName)
+}
+
+// GetWithdrawalRateWithContext is GetWithdrawalRate with a caller supplied context.
+func (c *Cactus) GetWithdrawalRateWithContext(ctx context.Context, coinName constants.CactusToken) (*GetWithdrawalRateResp, error) {
+	params := url.Values{
+		"coin_name": {string(coinName)},
 	}
-	resp, err := c.get(GetWithdrawalRateUrl, params)
+	resp, err := c.get(ctx, GetWithdrawalRateUrl, params)
 	if err != nil {
 		return nil, err
 	}
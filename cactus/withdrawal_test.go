@@ -0,0 +1,111 @@
+package cactus
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"io"
+	"net/http"
+	"testing"
+)
+
+var benchWithdrawal = WithdrawalArgsFeeReq{
+	FromWalletCode: "wallet-code",
+	CoinName:       "USDT_ETH",
+	OrderNo:        "order-0001",
+	Description:    "payout",
+	FeeRateLevel:   "MEDIUM",
+	DestAddressItemList: DestAddressItem{
+		Amount:      NewAmount(1500000),
+		DestAddress: "0x52908400098527886E0F7030069857D2E4169EE7",
+		Remark:      "invoice 42",
+	},
+}
+
+func TestPostSendsSignedBytes(t *testing.T) {
+	req := CreateContractOrderReq{
+		OrderNo:        "order-1",
+		FromWalletCode: "wallet-code",
+		FromAddress:    "0x52908400098527886E0F7030069857D2E4169EE7",
+		ToAddress:      "0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+		Amount:         MustParseAmount("1000000000000000001"),
+		Chain:          constants.ChainName("ETH"),
+		ContractData:   "0x",
+		// Above int64, a map round trip would have rounded it
+		GasPrice: MustParseAmount("100000000000000000001"),
+		GasLimit: 21000,
+	}
+	want, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body []byte
+	var contentHash string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		contentHash = r.Header.Get("Content-SHA256")
+		w.Write([]byte(`{"code":0,"successful":true,"data":{"OrderNo":"order-1"}}`))
+	}))
+	if _, err = client.CreateContractOrder("b", req); err != nil {
+		t.Fatalf("CreateContractOrder() error = %v", err)
+	}
+	if !bytes.Equal(body, want) {
+		t.Errorf("sent body %s, want %s", body, want)
+	}
+	for _, wire := range []string{`"amount":"1000000000000000001"`, `"gas_price":100000000000000000001`} {
+		if !bytes.Contains(body, []byte(wire)) {
+			t.Errorf("sent body %s, want %s", body, wire)
+		}
+	}
+	if bytes.Contains(body, []byte("max_fee_per_gas")) {
+		t.Errorf("sent body %s, want unset gas prices left out", body)
+	}
+	sum := sha256.Sum256(body)
+	if contentHash != base64.StdEncoding.EncodeToString(sum[:]) {
+		t.Errorf("Content-SHA256 %q does not match the sent body", contentHash)
+	}
+}
+
+// BenchmarkWithdrawalBody compares the former struct to map to json encoding with the direct one
+func BenchmarkWithdrawalBody(b *testing.B) {
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			reqBytes, err := json.Marshal(benchWithdrawal)
+			if err != nil {
+				b.Fatal(err)
+			}
+			var reqMap map[string]interface{}
+			if err = json.Unmarshal(reqBytes, &reqMap); err != nil {
+				b.Fatal(err)
+			}
+			if _, err = json.Marshal(reqMap); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("typed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := encodeBody(benchWithdrawal); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkCreateWithdrawOrder(b *testing.B) {
+	client := newTestClient(b, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Write([]byte(`{"code":0,"successful":true,"data":{"OrderNo":"order-0001"}}`))
+	}))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.CreateWithdrawOrder("b", benchWithdrawal); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
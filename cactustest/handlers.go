@@ -0,0 +1,981 @@
+package cactustest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultLimit = 10
+
+// amount accepts base unit amounts sent as json numbers or strings
+type amount int64
+
+func (a *amount) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		*a = 0
+		return nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount %s", data)
+	}
+	*a = amount(v)
+	return nil
+}
+
+// listParam returns the values of a multi valued parameter, cactus sends them comma joined
+func listParam(q url.Values, key string) []string {
+	var list []string
+	for _, value := range q[key] {
+		for _, item := range strings.Split(value, ",") {
+			if item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+// matches reports whether value passes a list filter, an empty filter matches everything
+func matches(filter []string, value string) bool {
+	return len(filter) == 0 || slices.Contains(filter, value)
+}
+
+// inTimeRange checks t against the start_time and end_time millisecond parameters
+func inTimeRange(q url.Values, t time.Time) bool {
+	if start, err := strconv.ParseInt(q.Get("start_time"), 10, 64); err == nil && t.UnixMilli() < start {
+		return false
+	}
+	if end, err := strconv.ParseInt(q.Get("end_time"), 10, 64); err == nil && t.UnixMilli() > end {
+		return false
+	}
+	return true
+}
+
+// newestFirst reports whether a list is sorted descending, items are stored oldest first
+func newestFirst(q url.Values, key string) bool {
+	value := q.Get(key)
+	return value == "" || value == strconv.Itoa(int(constants.OrderTypeDesc)) || strings.EqualFold(value, "DESC")
+}
+
+func reverseIf[T any](list []T, reverse bool) []T {
+	if reverse {
+		slices.Reverse(list)
+	}
+	return list
+}
+
+// paginate cuts one page out of list, answering with the cactus list envelope
+func paginate[T any](q url.Values, list []T) map[string]any {
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+	total := len(list)
+	offset = min(max(offset, 0), total)
+	page := list[offset:min(offset+limit, total)]
+	if page == nil {
+		page = []T{}
+	}
+	return map[string]any{"offset": offset, "limit": limit, "total": total, "list": page}
+}
+
+func (s *Server) coinInfos(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	list := []map[string]any{}
+	for _, coin := range s.coins {
+		if !matches(listParam(q, "cactus_symbol"), coin.CactusSymbol) || !matches(listParam(q, "symbol"), coin.Symbol) {
+			continue
+		}
+		list = append(list, map[string]any{
+			"cactus_symbol":        coin.CactusSymbol,
+			"symbol":               coin.Symbol,
+			"chain":                string(coin.Chain),
+			"cactus_chain":         string(coin.Chain),
+			"decimals":             strconv.Itoa(coin.Decimals),
+			"contract_address":     coin.ContractAddress,
+			"deposit_block_number": strconv.Itoa(coin.ConfirmBlockNumber),
+			"confirm_block_number": strconv.Itoa(coin.ConfirmBlockNumber),
+		})
+	}
+	return list, nil
+}
+
+func (s *Server) chainInfos(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	list := []map[string]any{}
+	for _, chain := range s.chains {
+		if !matches(listParam(q, "chain"), string(chain.Chain)) || !matches(listParam(q, "full_name"), chain.FullName) {
+			continue
+		}
+		list = append(list, map[string]any{
+			"chain":                string(chain.Chain),
+			"full_name":            chain.FullName,
+			"main_coin":            chain.MainCoin,
+			"evm_chain":            chain.EvmChain,
+			"support_eip1559":      chain.SupportEip1559,
+			"confirm_block_number": chain.ConfirmBlockNumber,
+			"miner_block_number":   chain.ConfirmBlockNumber,
+		})
+	}
+	return list, nil
+}
+
+func (s *Server) walletView(w *Wallet, coinName string) map[string]any {
+	addressNum := 0
+	for _, a := range s.addresses {
+		if a.BId == w.BId && a.WalletCode == w.Code {
+			addressNum++
+		}
+	}
+	coin, _ := s.coin(coinName)
+	usd := s.value(coinName, w.Balances[coinName])
+	return map[string]any{
+		"domain_id":                domainID,
+		"b_id":                     w.BId,
+		"wallet_code":              w.Code,
+		"wallet_name":              w.Name,
+		"coin_name":                coinName,
+		"contract_address":         coin.ContractAddress,
+		"wallet_type":              w.Type,
+		"storage_type":             w.StorageType,
+		"available_amount":         w.Balances[coinName] - w.Frozen[coinName],
+		"freeze_amount":            w.Frozen[coinName],
+		"total_amount":             w.Balances[coinName],
+		"usd_total_market":         usd,
+		"cny_total_market":         0,
+		"coin_status":              "NORMAL",
+		"chinese_reason_of_status": "",
+		"english_reason_of_status": "",
+		"normal_address_limit":     1000,
+		"normal_address_num":       addressNum,
+		"create_time":              w.CreateTime.UnixMilli(),
+	}
+}
+
+// walletCoins lists the coins a wallet holds, its main coin first
+func walletCoins(w *Wallet) []string {
+	coins := []string{w.CoinName}
+	others := make([]string, 0, len(w.Balances))
+	for coin := range w.Balances {
+		if coin != w.CoinName {
+			others = append(others, coin)
+		}
+	}
+	sort.Strings(others)
+	return append(coins, others...)
+}
+
+func (s *Server) listWallets(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	coinNames := listParam(q, "coin_names")
+	list := []map[string]any{}
+	for _, w := range s.wallets {
+		switch {
+		case q.Get("b_id") != "" && q.Get("b_id") != w.BId,
+			!matches(listParam(q, "wallet_types"), string(w.Type)),
+			q.Get("chain") != "" && q.Get("chain") != string(w.Chain),
+			q.Get("type") == string(constants.WalletFilterTypeHot) && w.StorageType != constants.StorageTypeHot,
+			q.Get("type") == string(constants.WalletFilterTypeCold) && w.StorageType != constants.StorageTypeCold,
+			!strings.Contains(w.Code+" "+w.Name, q.Get("keyword")):
+			continue
+		}
+		for _, coin := range walletCoins(w) {
+			if !matches(coinNames, coin) || (q.Get("hide_no_coin_wallet") == "true" && w.Balances[coin] == 0) {
+				continue
+			}
+			list = append(list, s.walletView(w, coin))
+		}
+	}
+	return paginate(q, reverseIf(list, newestFirst(q, "create_time_order"))), nil
+}
+
+func (s *Server) pathWallet(r *http.Request) (*Wallet, error) {
+	w := s.wallet(r.PathValue("bid"), r.PathValue("wallet"))
+	if w == nil {
+		return nil, notFound("wallet " + r.PathValue("wallet") + " not found")
+	}
+	return w, nil
+}
+
+func (s *Server) getWallet(r *http.Request) (any, error) {
+	w, err := s.pathWallet(r)
+	if err != nil {
+		return nil, err
+	}
+	coin := r.URL.Query().Get("coin_name")
+	if coin == "" {
+		coin = w.CoinName
+	}
+	return s.walletView(w, coin), nil
+}
+
+func (s *Server) createWallets(r *http.Request) (any, error) {
+	var req struct {
+		WalletType string `json:"wallet_type"`
+		Number     int    `json:"number"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.WalletType != "DEFI" {
+		return nil, badRequest("wallet_type must be DEFI")
+	}
+	if req.Number <= 0 || req.Number > 100 {
+		return nil, badRequest("number must be between 1 and 100")
+	}
+	codes := []string{}
+	for i := 0; i < req.Number; i++ {
+		w := s.addWallet(Wallet{BId: r.PathValue("bid"), Type: constants.WalletTypeDapp, Chain: constants.ChainNameETH})
+		codes = append(codes, w.Code)
+	}
+	return codes, nil
+}
+
+func addressView(a *Address, w *Wallet) map[string]any {
+	return map[string]any{
+		"address":            a.Address,
+		"address_storage":    w.StorageType,
+		"address_type":       a.Type,
+		"available_amount":   a.Balance,
+		"coin_name":          a.CoinName,
+		"bch_address_format": nil,
+		"b_id":               a.BId,
+		"description":        a.Description,
+		"domain_id":          domainID,
+		"freeze_amount":      0,
+		"total_amount":       a.Balance,
+		"wallet_code":        a.WalletCode,
+		"wallet_type":        w.Type,
+	}
+}
+
+func (s *Server) applyAddresses(r *http.Request) (any, error) {
+	w, err := s.pathWallet(r)
+	if err != nil {
+		return nil, err
+	}
+	var req struct {
+		AddressNum  int    `json:"address_num"`
+		AddressType string `json:"address_type"`
+		CoinName    string `json:"coin_name"`
+	}
+	if err = decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.AddressNum <= 0 || req.AddressNum > 100 {
+		return nil, badRequest("address_num must be between 1 and 100")
+	}
+	if req.AddressType == "" {
+		req.AddressType = "NORMAL_ADDRESS"
+	}
+	if req.CoinName == "" {
+		req.CoinName = w.CoinName
+	}
+	addresses := []string{}
+	for i := 0; i < req.AddressNum; i++ {
+		a := &Address{BId: w.BId, WalletCode: w.Code, Address: s.newAddress(w.Chain), CoinName: req.CoinName, Type: req.AddressType}
+		s.addresses = append(s.addresses, a)
+		addresses = append(addresses, a.Address)
+	}
+	return addresses, nil
+}
+
+func (s *Server) listAddresses(r *http.Request) (any, error) {
+	w, err := s.pathWallet(r)
+	if err != nil {
+		return nil, err
+	}
+	q := r.URL.Query()
+	list := []map[string]any{}
+	for _, a := range s.addresses {
+		switch {
+		case a.BId != w.BId || a.WalletCode != w.Code,
+			q.Get("coin_name") != "" && q.Get("coin_name") != a.CoinName,
+			q.Get("hide_no_coin_address") == "true" && a.Balance == 0,
+			!strings.Contains(a.Address+" "+a.Description, q.Get("keyword")):
+			continue
+		}
+		list = append(list, addressView(a, w))
+	}
+	return paginate(q, list), nil
+}
+
+func (s *Server) pathAddress(r *http.Request) (*Address, *Wallet, error) {
+	w, err := s.pathWallet(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	a := s.address(w.BId, w.Code, r.PathValue("address"))
+	if a == nil {
+		return nil, nil, notFound("address not found")
+	}
+	return a, w, nil
+}
+
+func (s *Server) getAddress(r *http.Request) (any, error) {
+	a, w, err := s.pathAddress(r)
+	if err != nil {
+		return nil, err
+	}
+	return addressView(a, w), nil
+}
+
+func (s *Server) editAddress(r *http.Request) (any, error) {
+	a, _, err := s.pathAddress(r)
+	if err != nil {
+		return nil, err
+	}
+	var req struct {
+		Description string `json:"description"`
+	}
+	if err = decode(r, &req); err != nil {
+		return nil, err
+	}
+	a.Description = req.Description
+	return nil, nil
+}
+
+func (s *Server) verifyAddresses(r *http.Request) (any, error) {
+	var req struct {
+		CoinName  string   `json:"coin_name"`
+		Addresses []string `json:"addresses"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	bad := []string{}
+	for _, address := range req.Addresses {
+		if !s.validAddress(req.CoinName, address) {
+			bad = append(bad, address)
+		}
+	}
+	return bad, nil
+}
+
+type withdrawalReq struct {
+	FromAddress    string `json:"from_address"`
+	FromWalletCode string `json:"from_wallet_code"`
+	CoinName       string `json:"coin_name"`
+	OrderNo        string `json:"order_no"`
+	Description    string `json:"description"`
+	// DestAddressItemList is a list, a single object is accepted as well
+	DestAddressItemList json.RawMessage `json:"dest_address_item_list"`
+}
+
+type destItem struct {
+	Amount          amount `json:"amount"`
+	DestAddress     string `json:"dest_address"`
+	MemoType        string `json:"memo_type"`
+	Memo            string `json:"memo"`
+	IsAllWithdrawal bool   `json:"is_all_withdrawal"`
+	Remark          string `json:"remark"`
+}
+
+func (req *withdrawalReq) destinations() ([]destItem, error) {
+	raw := strings.TrimSpace(string(req.DestAddressItemList))
+	if raw == "" || raw == "null" {
+		return nil, badRequest("dest_address_item_list is required")
+	}
+	var items []destItem
+	if !strings.HasPrefix(raw, "[") {
+		raw = "[" + raw + "]"
+	}
+	if err := json.Unmarshal([]byte(raw), &items); err != nil {
+		return nil, badRequest("malformed dest_address_item_list: " + err.Error())
+	}
+	return items, nil
+}
+
+func (s *Server) estimateFee(r *http.Request) (any, error) {
+	var req withdrawalReq
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if s.wallet(r.PathValue("bid"), req.FromWalletCode) == nil {
+		return nil, badRequest("wallet " + req.FromWalletCode + " not found")
+	}
+	return s.MinerFee, nil
+}
+
+// newOrderNo checks a client supplied order number or generates one
+func (s *Server) newOrderNo(bId string, orderNo string) (string, error) {
+	if orderNo == "" {
+		return fmt.Sprintf("CT%010d", s.nextSeq()), nil
+	}
+	if s.order(bId, orderNo) != nil {
+		return "", badRequest("order no " + orderNo + " already exists")
+	}
+	return orderNo, nil
+}
+
+func (s *Server) createWithdrawal(r *http.Request) (any, error) {
+	var req withdrawalReq
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	bId := r.PathValue("bid")
+	w := s.wallet(bId, req.FromWalletCode)
+	if w == nil {
+		return nil, badRequest("wallet " + req.FromWalletCode + " not found")
+	}
+	if req.CoinName == "" {
+		req.CoinName = w.CoinName
+	}
+	items, err := req.destinations()
+	if err != nil {
+		return nil, err
+	}
+	available := w.Balances[req.CoinName] - w.Frozen[req.CoinName]
+	var total int64
+	dests := make([]Destination, 0, len(items))
+	for _, item := range items {
+		if !s.validAddress(req.CoinName, item.DestAddress) {
+			return nil, badRequest("invalid address " + item.DestAddress)
+		}
+		value := int64(item.Amount)
+		if item.IsAllWithdrawal {
+			value = available - total
+		}
+		if value <= 0 {
+			return nil, badRequest("amount must be positive")
+		}
+		total += value
+		dests = append(dests, Destination{Address: item.DestAddress, Amount: value, MemoType: item.MemoType, Memo: item.Memo, Remark: item.Remark})
+	}
+	if total > available {
+		return nil, badRequest("insufficient balance")
+	}
+	orderNo, err := s.newOrderNo(bId, req.OrderNo)
+	if err != nil {
+		return nil, err
+	}
+	w.Frozen[req.CoinName] += total
+	s.orders = append(s.orders, &Order{
+		BId:          bId,
+		OrderNo:      orderNo,
+		Kind:         OrderKindWithdraw,
+		WalletCode:   w.Code,
+		CoinName:     req.CoinName,
+		Chain:        w.Chain,
+		FromAddress:  req.FromAddress,
+		Destinations: dests,
+		Amount:       total,
+		MinerFee:     s.MinerFee,
+		Status:       OrderStatusProcessing,
+		Description:  req.Description,
+		Applicant:    s.KeyID,
+		CreateTime:   s.Now(),
+	})
+	return map[string]any{"OrderNo": orderNo}, nil
+}
+
+func (s *Server) orderView(o *Order) map[string]any {
+	w := s.wallet(o.BId, o.WalletCode)
+	dests := []map[string]any{}
+	for _, dest := range o.Destinations {
+		dests = append(dests, map[string]any{
+			"dest_address":   dest.Address,
+			"memo_type":      dest.MemoType,
+			"memo":           dest.Memo,
+			"origin_balance": dest.Amount,
+			"balance":        dest.Amount,
+			"remark":         dest.Remark,
+		})
+	}
+	return map[string]any{
+		"domain_id":                       domainID,
+		"exchange_rate":                   0,
+		"time_stamp":                      o.CreateTime.UnixMilli(),
+		"timestamp":                       o.CreateTime.UnixMilli(),
+		"order_no":                        o.OrderNo,
+		"applicant":                       o.Applicant,
+		"business_name":                   o.BId,
+		"coin_name":                       o.CoinName,
+		"wallet_type":                     w.Type,
+		"storage_type":                    w.StorageType,
+		"wallet_name":                     w.Name,
+		"wallet_code":                     w.Code,
+		"amount":                          o.Amount,
+		"original_amount":                 o.Amount,
+		"miner_fee_rate":                  0,
+		"miner_fee":                       o.MinerFee,
+		"description":                     o.Description,
+		"from_address":                    o.FromAddress,
+		"gas_price":                       o.GasPrice,
+		"gas_limit":                       o.GasLimit,
+		"order_dest_address_info_vo_list": dests,
+		"status":                          o.Status,
+		"inner_status":                    o.Status,
+		"bid":                             o.BId,
+	}
+}
+
+func (s *Server) listOrders(r *http.Request) (any, error) {
+	q := r.URL.Query()
+	list := []map[string]any{}
+	for _, o := range s.orders {
+		w := s.wallet(o.BId, o.WalletCode)
+		switch {
+		case o.BId != r.PathValue("bid"),
+			!matches(listParam(q, "applicant"), o.Applicant),
+			!matches(listParam(q, "coin_name"), o.CoinName),
+			!matches(listParam(q, "chain_name"), string(o.Chain)),
+			!matches(listParam(q, "wallet_name"), w.Name),
+			!matches(listParam(q, "status"), o.Status),
+			!strings.Contains(o.OrderNo+" "+o.Description, q.Get("keyword")),
+			!inTimeRange(q, o.CreateTime):
+			continue
+		}
+		list = append(list, s.orderView(o))
+	}
+	return paginate(q, reverseIf(list, newestFirst(q, "sort_by_time"))), nil
+}
+
+// pathOrder returns the withdrawal order in the path, contract and signature orders live under their wallet
+func (s *Server) pathOrder(r *http.Request) (*Order, error) {
+	o := s.order(r.PathValue("bid"), r.PathValue("order"))
+	if o == nil || o.Kind != OrderKindWithdraw {
+		return nil, notFound("order not found")
+	}
+	return o, nil
+}
+
+func (s *Server) getOrder(r *http.Request) (any, error) {
+	o, err := s.pathOrder(r)
+	if err != nil {
+		return nil, err
+	}
+	txs := []map[string]any{}
+	if o.TxHash != "" {
+		txs = append(txs, map[string]any{
+			"tx_type":      string(constants.TxTypeWithdraw),
+			"block_height": 0,
+			"tx_size":      0,
+			"tx_hash":      o.TxHash,
+			"gas_price":    o.GasPrice,
+			"gas_limit":    o.GasLimit,
+			"miner_fee":    strconv.FormatInt(o.MinerFee, 10),
+		})
+	}
+	return map[string]any{
+		"order_wallet_info":            s.orderView(o),
+		"tx_info_models":               txs,
+		"consolidation_tx_info_models": []any{},
+		"miner_fee_tx_info_models":     []any{},
+		"partial_failed":               []any{},
+		"partial_success":              []any{},
+	}, nil
+}
+
+// orderActionReq is the body of the accelerate and cancel endpoints
+type orderActionReq struct {
+	Level    constants.ReplaceByFeeLevel `json:"level"`
+	GasPrice float64                     `json:"gas_price"`
+}
+
+// bumpGasPrice raises the gas price of a processing order, by 10% per level or to a custom price
+func (s *Server) bumpGasPrice(r *http.Request, action string) (*Order, error) {
+	o, err := s.pathOrder(r)
+	if err != nil {
+		return nil, err
+	}
+	var req orderActionReq
+	if err = decode(r, &req); err != nil {
+		return nil, err
+	}
+	if o.Status != OrderStatusProcessing {
+		return nil, badRequest("order " + o.OrderNo + " is " + o.Status + " and can not be " + action)
+	}
+	if o.GasPrice == 0 {
+		o.GasPrice = 1000000000
+	}
+	switch req.Level {
+	case constants.ReplaceByFeeCustom:
+		if int64(req.GasPrice) <= o.GasPrice {
+			return nil, badRequest("gas_price must be higher than the current gas price")
+		}
+		o.GasPrice = int64(req.GasPrice)
+	case constants.ReplaceByFeeLevel1, constants.ReplaceByFeeLevel2, constants.ReplaceByFeeLevel3:
+		level, _ := strconv.Atoi(strings.TrimPrefix(string(req.Level), "LEVEL"))
+		o.GasPrice = int64(math.Ceil(float64(o.GasPrice) * (1 + 0.1*float64(level))))
+	default:
+		return nil, badRequest("unknown level " + string(req.Level))
+	}
+	return o, nil
+}
+
+func (s *Server) accelerateOrder(r *http.Request) (any, error) {
+	o, err := s.bumpGasPrice(r, "accelerated")
+	if err != nil {
+		return nil, err
+	}
+	return o.GasPrice, nil
+}
+
+func (s *Server) cancelOrder(r *http.Request) (any, error) {
+	o, err := s.bumpGasPrice(r, "canceled")
+	if err != nil {
+		return nil, err
+	}
+	s.release(o, OrderStatusCanceled)
+	return o.GasPrice, nil
+}
+
+func (s *Server) createContractOrder(r *http.Request) (any, error) {
+	var req struct {
+		OrderNo              string              `json:"order_no"`
+		FromWalletCode       string              `json:"from_wallet_code"`
+		FromAddress          string              `json:"from_address"`
+		ToAddress            string              `json:"to_address"`
+		Amount               amount              `json:"amount"`
+		Chain                constants.ChainName `json:"chain"`
+		ContractData         string              `json:"contract_data"`
+		GasPrice             int64               `json:"gas_price"`
+		MaxFeePerGas         int64               `json:"max_fee_per_gas"`
+		MaxPriorityFeePerGas int64               `json:"max_priority_fee_per_gas"`
+		GasLimit             int                 `json:"gas_limit"`
+		Description          string              `json:"description"`
+	}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	bId := r.PathValue("bid")
+	w := s.wallet(bId, req.FromWalletCode)
+	if w == nil {
+		return nil, badRequest("wallet " + req.FromWalletCode + " not found")
+	}
+	if req.Chain == "" {
+		req.Chain = w.Chain
+	}
+	chain, ok := s.chain(req.Chain)
+	if !ok || !chain.EvmChain {
+		return nil, badRequest("chain " + string(req.Chain) + " does not support contract calls")
+	}
+	if !s.validAddress(string(req.Chain), req.ToAddress) {
+		return nil, badRequest("invalid address " + req.ToAddress)
+	}
+	orderNo, err := s.newOrderNo(bId, req.OrderNo)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice := req.GasPrice
+	if gasPrice == 0 {
+		gasPrice = req.MaxFeePerGas
+	}
+	s.orders = append(s.orders, &Order{
+		BId:             bId,
+		OrderNo:         orderNo,
+		Kind:            OrderKindContract,
+		WalletCode:      w.Code,
+		CoinName:        chain.MainCoin,
+		Chain:           req.Chain,
+		FromAddress:     req.FromAddress,
+		ContractAddress: req.ToAddress,
+		ContractData:    req.ContractData,
+		Amount:          int64(req.Amount),
+		GasPrice:        gasPrice,
+		GasLimit:        req.GasLimit,
+		Status:          OrderStatusProcessing,
+		Description:     req.Description,
+		Applicant:       s.KeyID,
+		CreateTime:      s.Now(),
+	})
+	return map[string]any{"OrderNo": orderNo}, nil
+}
+
+func (s *Server) createSignOrder(r *http.Request) (any, error) {
+	w, err := s.pathWallet(r)
+	if err != nil {
+		return nil, err
+	}
+	var req struct {
+		Address          string              `json:"address"`
+		SignatureVersion string              `json:"signature_version"`
+		Payload          any                 `json:"payload"`
+		Chain            constants.ChainName `json:"chain"`
+		OrderNo          string              `json:"order_no"`
+		Description      string              `json:"description"`
+	}
+	if err = decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Address == "" || req.Payload == nil {
+		return nil, badRequest("address and payload are required")
+	}
+	if req.Chain == "" {
+		req.Chain = w.Chain
+	}
+	orderNo, err := s.newOrderNo(w.BId, req.OrderNo)
+	if err != nil {
+		return nil, err
+	}
+	s.orders = append(s.orders, &Order{
+		BId:         w.BId,
+		OrderNo:     orderNo,
+		Kind:        OrderKindSignature,
+		WalletCode:  w.Code,
+		Chain:       req.Chain,
+		FromAddress: req.Address,
+		Payload:     req.Payload,
+		Status:      OrderStatusProcessing,
+		Description: req.Description,
+		Applicant:   s.KeyID,
+		CreateTime:  s.Now(),
+	})
+	return map[string]any{"OrderNo": orderNo}, nil
+}
+
+func contractView(o *Order) map[string]any {
+	function := ""
+	if len(o.ContractData) >= 10 {
+		function = o.ContractData[:10]
+	}
+	return map[string]any{
+		"time_stamp":        o.CreateTime.UnixMilli(),
+		"miner_fee":         o.MinerFee,
+		"order_no":          o.OrderNo,
+		"contract_address":  o.ContractAddress,
+		"contract_function": function,
+		"gas_price":         o.GasPrice,
+		"gas_limit":         o.GasLimit,
+		"contract_data":     o.ContractData,
+		"applicant":         o.Applicant,
+		"status":            o.Status,
+		"amount":            o.Amount,
+		"description":       o.Description,
+		"tx_id":             o.TxHash,
+		"deposit_trans":     []any{},
+		"withdraw_trans":    []any{},
+	}
+}
+
+func (s *Server) listContractOrders(r *http.Request) (any, error) {
+	w, err := s.pathWallet(r)
+	if err != nil {
+		return nil, err
+	}
+	q := r.URL.Query()
+	list := []map[string]any{}
+	for _, o := range s.orders {
+		switch {
+		case o.BId != w.BId || o.WalletCode != w.Code || o.Kind != OrderKindContract,
+			q.Get("chain") != "" && q.Get("chain") != string(o.Chain),
+			!matches(listParam(q, "status"), o.Status),
+			!strings.Contains(o.OrderNo+" "+o.TxHash+" "+o.Description, q.Get("keyword")),
+			!inTimeRange(q, o.CreateTime):
+			continue
+		}
+		list = append(list, contractView(o))
+	}
+	return paginate(q, reverseIf(list, newestFirst(q, "sort_by_time"))), nil
+}
+
+func (s *Server) getContractOrder(r *http.Request) (any, error) {
+	w, err := s.pathWallet(r)
+	if err != nil {
+		return nil, err
+	}
+	o := s.order(w.BId, r.PathValue("order"))
+	if o == nil || o.WalletCode != w.Code || o.Kind != OrderKindContract {
+		return nil, notFound("order not found")
+	}
+	return contractView(o), nil
+}
+
+// walletDetails filters the history of the wallet in the path with the common tx query parameters
+func (s *Server) walletDetails(r *http.Request) (*Wallet, []*TxDetail, error) {
+	w, err := s.pathWallet(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	q := r.URL.Query()
+	var list []*TxDetail
+	for _, d := range s.details {
+		switch {
+		case d.BId != w.BId || d.WalletCode != w.Code,
+			q.Get("coin_name") != "" && q.Get("coin_name") != d.CoinName,
+			!matches(listParam(q, "tx_types"), string(d.TxType)),
+			!matches(listParam(q, "addresses"), d.Address),
+			q.Get("id") != "" && q.Get("id") != strconv.Itoa(d.ID),
+			q.Get("tx_id") != "" && q.Get("tx_id") != d.TxID,
+			q.Get("order_no") != "" && q.Get("order_no") != d.OrderNo,
+			!inTimeRange(q, d.TxTime):
+			continue
+		}
+		list = append(list, d)
+	}
+	return w, reverseIf(list, newestFirst(q, "create_time_order")), nil
+}
+
+func (s *Server) txSummaries(r *http.Request) (any, error) {
+	w, details, err := s.walletDetails(r)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]map[string]any, 0, len(details))
+	for _, d := range details {
+		list = append(list, map[string]any{
+			"wallet_code":       d.WalletCode,
+			"wallet_type":       w.Type,
+			"coin_name":         d.CoinName,
+			"order_no":          d.OrderNo,
+			"block_height":      d.BlockHeight,
+			"tx_id":             d.TxID,
+			"tx_type":           d.TxType,
+			"amount":            d.Amount,
+			"wallet_balance":    d.WalletBalance,
+			"remark_detail":     d.Remark,
+			"tx_time_stamp":     d.TxTime.UnixMilli(),
+			"create_time_stamp": d.TxTime.UnixMilli(),
+		})
+	}
+	return paginate(r.URL.Query(), list), nil
+}
+
+func (s *Server) txDetails(r *http.Request) (any, error) {
+	w, details, err := s.walletDetails(r)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]map[string]any, 0, len(details))
+	for _, d := range details {
+		deposit, withdraw := d.Amount, int64(0)
+		if d.Amount < 0 {
+			deposit, withdraw = 0, -d.Amount
+		}
+		list = append(list, map[string]any{
+			"id":              d.ID,
+			"domain_id":       domainID,
+			"wallet_code":     d.WalletCode,
+			"wallet_type":     w.Type,
+			"coin_name":       d.CoinName,
+			"order_no":        d.OrderNo,
+			"block_height":    d.BlockHeight,
+			"tx_id":           d.TxID,
+			"tx_size":         0,
+			"tx_type":         d.TxType,
+			"withdraw_amount": withdraw,
+			"tx_fee":          0,
+			"deposit_amount":  deposit,
+			"wallet_balance":  d.WalletBalance,
+			"extended_info":   map[string]any{"domain_coin_balance": d.WalletBalance, "attachments": nil},
+			"tx_status":       "SUCCESS",
+			"remark_detail":   d.Remark,
+			"vins":            []any{},
+			"vouts": []map[string]any{{
+				"address":   d.Address,
+				"idx":       0,
+				"amount":    max(d.Amount, -d.Amount),
+				"balance":   0,
+				"is_change": 0,
+				"desc":      "",
+			}},
+			"tx_time_stamp":     d.TxTime.UnixMilli(),
+			"create_time_stamp": d.TxTime.UnixMilli(),
+			"bid":               d.BId,
+		})
+	}
+	return paginate(r.URL.Query(), list), nil
+}
+
+func (s *Server) editRemark(r *http.Request) (any, error) {
+	w, err := s.pathWallet(r)
+	if err != nil {
+		return nil, err
+	}
+	var req struct {
+		Remark string `json:"remark"`
+	}
+	if err = decode(r, &req); err != nil {
+		return nil, err
+	}
+	for _, d := range s.details {
+		if d.BId == w.BId && d.WalletCode == w.Code && strconv.Itoa(d.ID) == r.PathValue("id") {
+			d.Remark = req.Remark
+			return nil, nil
+		}
+	}
+	return nil, notFound("wallet detail " + r.PathValue("id") + " not found")
+}
+
+// value converts a base unit amount to usd using Prices
+func (s *Server) value(coinName string, baseUnits int64) float64 {
+	coin, _ := s.coin(coinName)
+	return float64(baseUnits) / math.Pow10(coin.Decimals) * s.Prices[coinName]
+}
+
+func (s *Server) currentAsset(r *http.Request) (any, error) {
+	bId := r.URL.Query().Get("b_id")
+	var hot, cold float64
+	coins := []map[string]any{}
+	for _, w := range s.wallets {
+		if bId != "" && w.BId != bId {
+			continue
+		}
+		for _, coin := range walletCoins(w) {
+			if w.Balances[coin] == 0 {
+				continue
+			}
+			value := s.value(coin, w.Balances[coin])
+			if w.StorageType == constants.StorageTypeCold {
+				cold += value
+			} else {
+				hot += value
+			}
+			coins = append(coins, map[string]any{
+				"coin_name":  coin,
+				"amount":     w.Balances[coin],
+				"value":      value,
+				"value_cny":  0,
+				"store_type": w.StorageType,
+			})
+		}
+	}
+	return map[string]any{
+		"cold_market_value":      cold,
+		"cold_market_value_cny":  0,
+		"hot_market_value":       hot,
+		"hot_market_value_cny":   0,
+		"total_market_value":     hot + cold,
+		"total_market_value_cny": 0,
+		"coins":                  coins,
+	}, nil
+}
+
+func (s *Server) historyAsset(r *http.Request) (any, error) {
+	current, _ := s.currentAsset(r)
+	total := current.(map[string]any)["total_market_value"].(float64)
+	return map[string]any{
+		"history_asset_result": []map[string]any{{
+			"create_time":      s.Now().Format(time.DateOnly),
+			"market_value":     strconv.FormatFloat(total, 'f', 2, 64),
+			"market_value_cny": "0",
+		}},
+	}, nil
+}
+
+func (s *Server) feeRange(r *http.Request) (any, error) {
+	if r.URL.Query().Get("coin_name") == "" {
+		return nil, badRequest("coin_name is required")
+	}
+	return map[string]any{"minFeeRate": 1, "maxFeeRate": 100}, nil
+}
+
+func (s *Server) feeRates(r *http.Request) (any, error) {
+	if r.URL.Query().Get("coin_name") == "" {
+		return nil, badRequest("coin_name is required")
+	}
+	return []int64{10, 20, 30}, nil
+}
@@ -0,0 +1,237 @@
+// Package cactustest provides an in-process fake of the cactus custody api for tests
+//
+// The server checks request signatures with utils.Verifier, keeps wallets, addresses, orders and
+// transaction history in memory and serves every endpoint wrapped by the cactus package, so code
+// built on the sdk can be tested without network access or keys.
+package cactustest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/utils"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBId is the business id the server is seeded with
+	DefaultBId = "cactustest-b-id"
+	// DefaultAPIKey is the x-api-key the server accepts
+	DefaultAPIKey = "cactustest-api-key"
+	// DefaultKeyID is the api key id of Server.PrivateKey
+	DefaultKeyID = "cactustest-key-id"
+	// DefaultMaxSkew is how far the Date header may drift from the server clock
+	DefaultMaxSkew = 5 * time.Minute
+
+	apiPrefix = "/custody/v1/api"
+	project   = apiPrefix + "/projects/{bid}"
+	wallet    = project + "/wallets/{wallet}"
+)
+
+// Server is a fake cactus custody api listening on a local httptest server
+// Fields may be changed before the first request is sent.
+type Server struct {
+	*httptest.Server
+	// APIKey is the x-api-key every request has to carry
+	APIKey string
+	// KeyID and PrivateKey are a registered api key, NewClient signs with them
+	KeyID      string
+	PrivateKey *ecdsa.PrivateKey
+	// MaxSkew is how far the Date header may drift from Now, requests outside the window are rejected
+	MaxSkew time.Duration
+	// Now is the server clock
+	Now func() time.Time
+	// MinerFee is returned by the fee estimation endpoint
+	MinerFee int64
+	// Prices holds the usd price of one whole coin, used for asset values
+	Prices map[string]float64
+
+	mu           sync.Mutex
+	mux          *http.ServeMux
+	verifierOnce sync.Once
+	verifier     *utils.Verifier
+	keys         map[string]*ecdsa.PublicKey
+	coins        []Coin
+	chains       []Chain
+	wallets      []*Wallet
+	addresses    []*Address
+	orders       []*Order
+	details      []*TxDetail
+	seq          int
+}
+
+// NewServer starts a fake server seeded with a few coins and chains and a registered api key
+// The caller has to Close it.
+func NewServer() *Server {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic("cactustest: generate key: " + err.Error())
+	}
+	s := &Server{
+		APIKey:     DefaultAPIKey,
+		KeyID:      DefaultKeyID,
+		PrivateKey: key,
+		MaxSkew:    DefaultMaxSkew,
+		Now:        time.Now,
+		MinerFee:   2100,
+		Prices:     map[string]float64{},
+		mux:        http.NewServeMux(),
+		keys:       map[string]*ecdsa.PublicKey{DefaultKeyID: &key.PublicKey},
+		coins:      append([]Coin{}, defaultCoins...),
+		chains:     append([]Chain{}, defaultChains...),
+	}
+	s.routes()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewClient returns a cactus client signing with the server's registered key, dated by the server clock
+func (s *Server) NewClient() *cactus.Cactus {
+	client := cactus.NewCactus(s.URL, s.APIKey, s.KeyID, s.PrivateKey, s.Client(), -1)
+	client.Clock = func() time.Time { return s.Now() }
+	return client
+}
+
+// AddKey registers another api key, requests signed by it are accepted
+func (s *Server) AddKey(keyID string, pub *ecdsa.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[keyID] = pub
+}
+
+// apiError is turned into a cactus error response
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func badRequest(message string) error {
+	return &apiError{status: http.StatusBadRequest, message: message}
+}
+
+func notFound(message string) error {
+	return &apiError{status: http.StatusNotFound, message: message}
+}
+
+type handlerFunc func(r *http.Request) (any, error)
+
+// handle registers h, handlers run one at a time under the server lock
+func (s *Server) handle(pattern string, h handlerFunc) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		data, err := h(r)
+		s.mu.Unlock()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"code":       http.StatusOK,
+			"message":    "success",
+			"successful": true,
+			"data":       data,
+		})
+	})
+}
+
+func (s *Server) routes() {
+	s.handle("GET "+apiPrefix+"/wallets", s.listWallets)
+	s.handle("GET "+apiPrefix+"/coin-infos", s.coinInfos)
+	s.handle("GET "+apiPrefix+"/chain-infos", s.chainInfos)
+	s.handle("GET "+apiPrefix+"/asset", s.currentAsset)
+	s.handle("GET "+apiPrefix+"/history-asset", s.historyAsset)
+	s.handle("GET "+apiPrefix+"/customize-fee-rate/range", s.feeRange)
+	s.handle("GET "+apiPrefix+"/recommend-fee-rate/list", s.feeRates)
+	s.handle("POST "+apiPrefix+"/addresses/type/check", s.verifyAddresses)
+
+	s.handle("POST "+project+"/wallets/create", s.createWallets)
+	s.handle("GET "+wallet, s.getWallet)
+	s.handle("POST "+wallet+"/addresses/apply", s.applyAddresses)
+	s.handle("GET "+wallet+"/addresses", s.listAddresses)
+	s.handle("GET "+wallet+"/addresses/{address}", s.getAddress)
+	s.handle("POST "+wallet+"/addresses/{address}", s.editAddress)
+
+	s.handle("POST "+project+"/estimate-miner-fee", s.estimateFee)
+	s.handle("POST "+project+"/order/create", s.createWithdrawal)
+	s.handle("GET "+project+"/orders", s.listOrders)
+	s.handle("GET "+project+"/orders/{order}", s.getOrder)
+	s.handle("POST "+project+"/orders/{order}/accelerate", s.accelerateOrder)
+	s.handle("POST "+project+"/orders/{order}/cancel", s.cancelOrder)
+
+	s.handle("POST "+project+"/contract/call", s.createContractOrder)
+	s.handle("POST "+wallet+"/signatures", s.createSignOrder)
+	s.handle("GET "+wallet+"/contract/orders", s.listContractOrders)
+	s.handle("GET "+wallet+"/contract/orders/{order}", s.getContractOrder)
+
+	s.handle("GET "+wallet+"/tx-summaries", s.txSummaries)
+	s.handle("GET "+wallet+"/tx-details", s.txDetails)
+	s.handle("POST "+wallet+"/details/{id}", s.editRemark)
+
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, notFound("no such endpoint "+r.Method+" "+r.URL.Path))
+	})
+}
+
+// ServeHTTP authenticates the request and dispatches it to the endpoint handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := s.authenticate(r); err != nil {
+		writeError(w, &apiError{status: http.StatusUnauthorized, message: err.Error()})
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// authenticate checks the api key and the request signature the way cactus does
+func (s *Server) authenticate(r *http.Request) error {
+	if r.Header.Get("x-api-key") != s.APIKey {
+		return errors.New("invalid api key")
+	}
+	s.verifierOnce.Do(func() {
+		s.verifier = utils.NewVerifier(s.publicKey, s.MaxSkew)
+		s.verifier.Now = func() time.Time { return s.Now() }
+	})
+	_, err := s.verifier.VerifyAuthorizationHeader(r)
+	return err
+}
+
+func (s *Server) publicKey(keyID string) (*ecdsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys[keyID], nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		apiErr = &apiError{status: http.StatusInternalServerError, message: err.Error()}
+	}
+	writeJSON(w, apiErr.status, map[string]any{
+		"code":       apiErr.status,
+		"message":    apiErr.message,
+		"successful": false,
+	})
+}
+
+// decode reads the json request body into v
+func decode(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badRequest("malformed request body: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package cactustest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/utils"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWithdrawalFlow(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.NewClient()
+	w := srv.AddWallet(Wallet{CoinName: "ETH"})
+
+	applied, err := client.ApplyNewAddress(DefaultBId, w.Code, "ETH", 1, "NORMAL_ADDRESS")
+	if err != nil {
+		t.Fatalf("ApplyNewAddress() error = %v", err)
+	}
+	address := applied.Data[0]
+	if _, err = srv.Deposit(DefaultBId, w.Code, "ETH", address, 1000); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := "0x00000000000000000000000000000000000000aa"
+	req := cactus.WithdrawalArgsFeeReq{
+		FromWalletCode:      w.Code,
+		CoinName:            "ETH",
+		OrderNo:             "order-1",
+		DestAddressItemList: cactus.DestAddressItem{Amount: cactus.NewAmount(400), DestAddress: dest},
+	}
+	created, err := client.CreateWithdrawOrder(DefaultBId, req)
+	if err != nil {
+		t.Fatalf("CreateWithdrawOrder() error = %v", err)
+	}
+	if created.Data.OrderNo != "order-1" {
+		t.Errorf("OrderNo = %q, want order-1", created.Data.OrderNo)
+	}
+	if _, err = client.CreateWithdrawOrder(DefaultBId, cactus.WithdrawalArgsFeeReq{
+		FromWalletCode:      w.Code,
+		CoinName:            "ETH",
+		DestAddressItemList: cactus.DestAddressItem{Amount: cactus.NewAmount(700), DestAddress: dest},
+	}); !errors.Is(err, cactus.ErrInsufficientBalance) {
+		t.Errorf("CreateWithdrawOrder() over the available balance error = %v, want %v", err, cactus.ErrInsufficientBalance)
+	}
+	if _, err = client.CreateWithdrawOrder(DefaultBId, cactus.WithdrawalArgsFeeReq{
+		FromWalletCode:      w.Code,
+		CoinName:            "ETH",
+		DestAddressItemList: cactus.DestAddressItem{Amount: cactus.NewAmount(1), DestAddress: "not an address"},
+	}); !errors.Is(err, cactus.ErrInvalidAddress) {
+		t.Errorf("CreateWithdrawOrder() to a bad address error = %v, want %v", err, cactus.ErrInvalidAddress)
+	}
+
+	if _, err = srv.CompleteOrder(DefaultBId, "order-1"); err != nil {
+		t.Fatal(err)
+	}
+	details, err := client.GetOrderDetails(DefaultBId, "order-1")
+	if err != nil {
+		t.Fatalf("GetOrderDetails() error = %v", err)
+	}
+	if details.Data.OrderWalletInfo.Status != OrderStatusCompleted || len(details.Data.TxInfoModels) != 1 {
+		t.Errorf("GetOrderDetails() = %+v, want a completed order with one tx", details.Data)
+	}
+	if _, err = client.GetOrderDetails(DefaultBId, "missing"); !errors.Is(err, cactus.ErrOrderNotFound) {
+		t.Errorf("GetOrderDetails() of a missing order error = %v, want %v", err, cactus.ErrOrderNotFound)
+	}
+
+	wallet, err := client.GetSingleWalletInfo(DefaultBId, w.Code, "ETH")
+	if err != nil {
+		t.Fatalf("GetSingleWalletInfo() error = %v", err)
+	}
+	if wallet.Data.TotalAmount.Cmp(cactus.NewAmount(600)) != 0 || !wallet.Data.FreezeAmount.IsZero() {
+		t.Errorf("wallet total %s frozen %s, want 600 and 0", wallet.Data.TotalAmount, wallet.Data.FreezeAmount)
+	}
+	history, err := client.GetWalletTransactionHistory(DefaultBId, w.Code, "ETH", []constants.TxType{constants.TxTypeDeposit, constants.TxTypeWithdraw},
+		nil, 0, 0, constants.OrderTypeAsc, 0, 0)
+	if err != nil {
+		t.Fatalf("GetWalletTransactionHistory() error = %v", err)
+	}
+	if history.Data.Total != 2 || history.Data.List[0].TxType != string(constants.TxTypeDeposit) || history.Data.List[1].Amount.Cmp(cactus.NewAmount(-400)) != 0 {
+		t.Errorf("GetWalletTransactionHistory() = %+v", history.Data)
+	}
+}
+
+func TestContractOrder(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.NewClient()
+	codes, err := client.CreateWallet(DefaultBId, "DEFI", 1)
+	if err != nil {
+		t.Fatalf("CreateWallet() error = %v", err)
+	}
+	code := codes.Data[0]
+	_, err = client.CreateContractOrder(DefaultBId, cactus.CreateContractOrderReq{
+		OrderNo:        "call-1",
+		FromWalletCode: code,
+		ToAddress:      "0xdac17f958d2ee523a2206206994597c13d831ec7",
+		Amount:         cactus.NewAmount(0),
+		Chain:          constants.ChainNameETH,
+		ContractData:   "0xa9059cbb",
+		GasLimit:       60000,
+	})
+	if err != nil {
+		t.Fatalf("CreateContractOrder() error = %v", err)
+	}
+	if _, err = client.GetOrderDetails(DefaultBId, "call-1"); !errors.Is(err, cactus.ErrOrderNotFound) {
+		t.Errorf("GetOrderDetails() of a contract order error = %v, want %v", err, cactus.ErrOrderNotFound)
+	}
+	if _, err = srv.CompleteOrder(DefaultBId, "call-1"); err != nil {
+		t.Fatal(err)
+	}
+	details, err := client.GetDefiTransactionDetails(DefaultBId, code, "call-1")
+	if err != nil {
+		t.Fatalf("GetDefiTransactionDetails() error = %v", err)
+	}
+	if details.Data.Status != OrderStatusCompleted || details.Data.TxId == "" {
+		t.Errorf("GetDefiTransactionDetails() = %+v, want a completed order with a tx id", details.Data)
+	}
+}
+
+// signedRequest builds a request the way cactus.Cactus signs it
+func signedRequest(t *testing.T, srv *Server, method string, path string, body []byte, date string, nonce string) *http.Request {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, srv.URL+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signString := utils.GenerateSignString(method, body, srv.APIKey, nonce, path, "", date)
+	header, err := utils.GenerateAuthorizationHeader([]byte(signString), srv.KeyID, srv.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != nil {
+		sum := sha256.Sum256(body)
+		req.Header.Set("Content-SHA256", base64.StdEncoding.EncodeToString(sum[:]))
+	}
+	req.Header.Set("x-api-key", srv.APIKey)
+	req.Header.Set("x-api-nonce", nonce)
+	req.Header.Set("Date", date)
+	req.Header.Set("Authorization", header)
+	return req
+}
+
+func TestAuthentication(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	now := utils.GetCurrentGmtTime()
+	stale := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	body := []byte(`{"wallet_type":"DEFI","number":1}`)
+	path := "/custody/v1/api/projects/" + DefaultBId + "/wallets/create"
+
+	replayed := signedRequest(t, srv, http.MethodGet, "/custody/v1/api/chain-infos", nil, now, "nonce-1")
+	tampered := signedRequest(t, srv, http.MethodPost, path, body, now, "nonce-3")
+	tampered.Body = io.NopCloser(bytes.NewReader([]byte(`{"wallet_type":"DEFI","number":2}`)))
+	forged := signedRequest(t, srv, http.MethodGet, "/custody/v1/api/chain-infos", nil, now, "nonce-4")
+	forged.Header.Set("Authorization", "api "+srv.KeyID+":"+base64.StdEncoding.EncodeToString([]byte("forged")))
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"valid", replayed, http.StatusOK},
+		{"replayed nonce", replayed.Clone(context.Background()), http.StatusUnauthorized},
+		{"stale date", signedRequest(t, srv, http.MethodGet, "/custody/v1/api/chain-infos", nil, stale, "nonce-2"), http.StatusUnauthorized},
+		{"body does not match content sha256", tampered, http.StatusUnauthorized},
+		{"bad signature", forged, http.StatusUnauthorized},
+		{"signed post", signedRequest(t, srv, http.MethodPost, path, body, now, "nonce-5"), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := srv.Client().Do(tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,433 @@
+package cactustest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const domainID = "cactustest-domain"
+
+// Order kinds
+const (
+	OrderKindWithdraw  = "WITHDRAW"
+	OrderKindContract  = "CONTRACT"
+	OrderKindSignature = "SIGNATURE"
+)
+
+// Order statuses, new orders stay OrderStatusProcessing until CompleteOrder or FailOrder is called
+const (
+	OrderStatusProcessing = "PROCESSING"
+	OrderStatusCompleted  = "COMPLETED"
+	OrderStatusFailed     = "FAILED"
+	OrderStatusCanceled   = "CANCELED"
+)
+
+// Coin is a coin-infos entry
+type Coin struct {
+	CactusSymbol       string
+	Symbol             string
+	Chain              constants.ChainName
+	Decimals           int
+	ContractAddress    string
+	ConfirmBlockNumber int
+}
+
+// Chain is a chain-infos entry
+type Chain struct {
+	Chain              constants.ChainName
+	FullName           string
+	MainCoin           string
+	EvmChain           bool
+	SupportEip1559     bool
+	ConfirmBlockNumber int
+}
+
+var defaultChains = []Chain{
+	{Chain: constants.ChainNameBTC, FullName: "Bitcoin", MainCoin: "BTC", ConfirmBlockNumber: 2},
+	{Chain: constants.ChainNameETH, FullName: "Ethereum", MainCoin: "ETH", EvmChain: true, SupportEip1559: true, ConfirmBlockNumber: 12},
+	{Chain: constants.ChainNameBSC, FullName: "BNB Smart Chain", MainCoin: "BNB_BSC", EvmChain: true, ConfirmBlockNumber: 15},
+}
+
+var defaultCoins = []Coin{
+	{CactusSymbol: "BTC", Symbol: "BTC", Chain: constants.ChainNameBTC, Decimals: 8, ConfirmBlockNumber: 2},
+	{CactusSymbol: "ETH", Symbol: "ETH", Chain: constants.ChainNameETH, Decimals: 18, ConfirmBlockNumber: 12},
+	{CactusSymbol: "USDT", Symbol: "USDT", Chain: constants.ChainNameETH, Decimals: 6, ContractAddress: "0xdac17f958d2ee523a2206206994597c13d831ec7", ConfirmBlockNumber: 12},
+	{CactusSymbol: "BNB_BSC", Symbol: "BNB", Chain: constants.ChainNameBSC, Decimals: 18, ConfirmBlockNumber: 15},
+}
+
+// Wallet is a custody wallet, Balances and Frozen are in the coin's base unit
+type Wallet struct {
+	BId         string
+	Code        string
+	Name        string
+	Type        constants.WalletType
+	StorageType constants.StorageType
+	Chain       constants.ChainName
+	CoinName    string // main coin
+	Balances    map[string]int64
+	Frozen      map[string]int64
+	CreateTime  time.Time
+}
+
+// Address is an address applied for a wallet
+type Address struct {
+	BId         string
+	WalletCode  string
+	Address     string
+	CoinName    string
+	Type        string
+	Description string
+	Balance     int64
+}
+
+// Destination is one output of a withdrawal order
+type Destination struct {
+	Address  string
+	Amount   int64
+	MemoType string
+	Memo     string
+	Remark   string
+}
+
+// Order is a withdrawal, contract call or signature order
+type Order struct {
+	BId             string
+	OrderNo         string
+	Kind            string
+	WalletCode      string
+	CoinName        string
+	Chain           constants.ChainName
+	FromAddress     string
+	Destinations    []Destination
+	ContractAddress string
+	ContractData    string
+	Payload         any
+	Amount          int64
+	GasPrice        int64
+	GasLimit        int
+	MinerFee        int64
+	Status          string
+	TxHash          string
+	Description     string
+	Applicant       string
+	CreateTime      time.Time
+}
+
+// TxDetail is a wallet history entry
+type TxDetail struct {
+	ID            int
+	BId           string
+	WalletCode    string
+	CoinName      string
+	OrderNo       string
+	TxID          string
+	TxType        constants.TxType
+	Address       string
+	Amount        int64
+	WalletBalance int64
+	Remark        string
+	BlockHeight   int
+	TxTime        time.Time
+}
+
+// AddCoin adds or replaces a coin-infos entry
+func (s *Server) AddCoin(coin Coin) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.coins {
+		if s.coins[i].CactusSymbol == coin.CactusSymbol {
+			s.coins[i] = coin
+			return
+		}
+	}
+	s.coins = append(s.coins, coin)
+}
+
+// AddChain adds or replaces a chain-infos entry
+func (s *Server) AddChain(chain Chain) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.chains {
+		if s.chains[i].Chain == chain.Chain {
+			s.chains[i] = chain
+			return
+		}
+	}
+	s.chains = append(s.chains, chain)
+}
+
+// AddWallet stores a wallet and returns it with defaults filled in
+// BId defaults to DefaultBId, Code is generated, Type to DAPP_ADDRESS and StorageType to hot.
+func (s *Server) AddWallet(w Wallet) Wallet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addWallet(w)
+}
+
+func (s *Server) addWallet(w Wallet) *Wallet {
+	if w.BId == "" {
+		w.BId = DefaultBId
+	}
+	if w.Code == "" {
+		w.Code = fmt.Sprintf("%d", 100000+s.nextSeq())
+	}
+	if w.Name == "" {
+		w.Name = "wallet-" + w.Code
+	}
+	if w.Type == "" {
+		w.Type = constants.WalletTypeDapp
+	}
+	if w.StorageType == "" {
+		w.StorageType = constants.StorageTypeHot
+	}
+	if w.Chain == "" {
+		w.Chain = constants.ChainNameETH
+		if coin, ok := s.coin(w.CoinName); ok {
+			w.Chain = coin.Chain
+		}
+	}
+	if w.CoinName == "" {
+		w.CoinName = string(w.Chain)
+		if chain, ok := s.chain(w.Chain); ok {
+			w.CoinName = chain.MainCoin
+		}
+	}
+	balances := map[string]int64{}
+	for coin, amount := range w.Balances {
+		balances[coin] = amount
+	}
+	w.Balances = balances
+	w.Frozen = map[string]int64{}
+	if w.CreateTime.IsZero() {
+		w.CreateTime = s.Now()
+	}
+	stored := &w
+	s.wallets = append(s.wallets, stored)
+	return stored
+}
+
+// Wallet returns a copy of a stored wallet
+func (s *Server) Wallet(bId string, code string) (Wallet, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := s.wallet(bId, code)
+	if w == nil {
+		return Wallet{}, false
+	}
+	return w.copy(), true
+}
+
+func (w *Wallet) copy() Wallet {
+	c := *w
+	c.Balances = map[string]int64{}
+	c.Frozen = map[string]int64{}
+	for coin, amount := range w.Balances {
+		c.Balances[coin] = amount
+	}
+	for coin, amount := range w.Frozen {
+		c.Frozen[coin] = amount
+	}
+	return c
+}
+
+// Deposit credits amount of coin to a wallet and records a DEPOSIT history entry
+// address may be empty, if it belongs to the wallet its balance is credited too.
+func (s *Server) Deposit(bId string, walletCode string, coin string, address string, amount int64) (TxDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := s.wallet(bId, walletCode)
+	if w == nil {
+		return TxDetail{}, fmt.Errorf("cactustest: wallet %s not found", walletCode)
+	}
+	w.Balances[coin] += amount
+	if a := s.address(bId, walletCode, address); a != nil {
+		a.Balance += amount
+	}
+	detail := s.addDetail(w, coin, "", s.txHash(w.Chain), constants.TxTypeDeposit, address, amount)
+	return *detail, nil
+}
+
+// Order returns a copy of a stored order
+func (s *Server) Order(bId string, orderNo string) (Order, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o := s.order(bId, orderNo)
+	if o == nil {
+		return Order{}, false
+	}
+	return *o, true
+}
+
+// CompleteOrder settles a processing order: it gets a tx hash, withdrawals leave the wallet
+// and are recorded in the wallet history
+func (s *Server) CompleteOrder(bId string, orderNo string) (Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.processingOrder(bId, orderNo)
+	if err != nil {
+		return Order{}, err
+	}
+	o.Status = OrderStatusCompleted
+	if o.Kind != OrderKindSignature {
+		o.TxHash = s.txHash(o.Chain)
+	}
+	if o.Kind == OrderKindWithdraw {
+		w := s.wallet(o.BId, o.WalletCode)
+		w.Frozen[o.CoinName] -= o.Amount
+		w.Balances[o.CoinName] -= o.Amount
+		if a := s.address(o.BId, o.WalletCode, o.FromAddress); a != nil {
+			a.Balance -= o.Amount
+		}
+		for _, dest := range o.Destinations {
+			s.addDetail(w, o.CoinName, o.OrderNo, o.TxHash, constants.TxTypeWithdraw, dest.Address, -dest.Amount)
+		}
+	}
+	return *o, nil
+}
+
+// FailOrder marks a processing order failed and releases the frozen amount
+func (s *Server) FailOrder(bId string, orderNo string) (Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.processingOrder(bId, orderNo)
+	if err != nil {
+		return Order{}, err
+	}
+	s.release(o, OrderStatusFailed)
+	return *o, nil
+}
+
+func (s *Server) processingOrder(bId string, orderNo string) (*Order, error) {
+	o := s.order(bId, orderNo)
+	if o == nil {
+		return nil, fmt.Errorf("cactustest: order %s not found", orderNo)
+	}
+	if o.Status != OrderStatusProcessing {
+		return nil, fmt.Errorf("cactustest: order %s is %s", orderNo, o.Status)
+	}
+	return o, nil
+}
+
+// release ends a processing order without moving funds
+func (s *Server) release(o *Order, status string) {
+	o.Status = status
+	if o.Kind == OrderKindWithdraw {
+		s.wallet(o.BId, o.WalletCode).Frozen[o.CoinName] -= o.Amount
+	}
+}
+
+func (s *Server) addDetail(w *Wallet, coin string, orderNo string, txID string, txType constants.TxType, address string, amount int64) *TxDetail {
+	detail := &TxDetail{
+		ID:            s.nextSeq(),
+		BId:           w.BId,
+		WalletCode:    w.Code,
+		CoinName:      coin,
+		OrderNo:       orderNo,
+		TxID:          txID,
+		TxType:        txType,
+		Address:       address,
+		Amount:        amount,
+		WalletBalance: w.Balances[coin],
+		BlockHeight:   1000000 + len(s.details),
+		TxTime:        s.Now(),
+	}
+	s.details = append(s.details, detail)
+	return detail
+}
+
+func (s *Server) nextSeq() int {
+	s.seq++
+	return s.seq
+}
+
+func (s *Server) wallet(bId string, code string) *Wallet {
+	for _, w := range s.wallets {
+		if w.BId == bId && w.Code == code {
+			return w
+		}
+	}
+	return nil
+}
+
+func (s *Server) address(bId string, walletCode string, address string) *Address {
+	for _, a := range s.addresses {
+		if a.BId == bId && a.WalletCode == walletCode && a.Address == address {
+			return a
+		}
+	}
+	return nil
+}
+
+func (s *Server) order(bId string, orderNo string) *Order {
+	for _, o := range s.orders {
+		if o.BId == bId && o.OrderNo == orderNo {
+			return o
+		}
+	}
+	return nil
+}
+
+func (s *Server) coin(name string) (Coin, bool) {
+	for _, coin := range s.coins {
+		if coin.CactusSymbol == name {
+			return coin, true
+		}
+	}
+	return Coin{}, false
+}
+
+func (s *Server) chain(name constants.ChainName) (Chain, bool) {
+	for _, chain := range s.chains {
+		if chain.Chain == name {
+			return chain, true
+		}
+	}
+	return Chain{}, false
+}
+
+func (s *Server) isEVM(chain constants.ChainName) bool {
+	info, ok := s.chain(chain)
+	return ok && info.EvmChain
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (s *Server) newAddress(chain constants.ChainName) string {
+	if s.isEVM(chain) {
+		return "0x" + randomHex(20)
+	}
+	return "bc1q" + randomHex(19)
+}
+
+func (s *Server) txHash(chain constants.ChainName) string {
+	if s.isEVM(chain) {
+		return "0x" + randomHex(32)
+	}
+	return randomHex(32)
+}
+
+var (
+	evmAddress   = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	otherAddress = regexp.MustCompile(`^[0-9A-Za-z]{20,90}$`)
+)
+
+// validAddress is a format check only, EVM chains need a 0x hex address
+func (s *Server) validAddress(coinName string, address string) bool {
+	chain := constants.ChainName(coinName)
+	if coin, ok := s.coin(coinName); ok {
+		chain = coin.Chain
+	}
+	if s.isEVM(chain) {
+		return evmAddress.MatchString(address)
+	}
+	return otherAddress.MatchString(address) && !strings.HasPrefix(address, "0x")
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"iter"
+	"strconv"
+)
+
+func runAddresses(args []string) error {
+	return subcommands("addresses", map[string]command{
+		"apply":    {usage: "apply for new addresses of a wallet", run: runAddressesApply},
+		"list":     {usage: "list the addresses of a wallet", run: runAddressesList},
+		"describe": {usage: "set the description of an address", run: runAddressesDescribe},
+		"verify":   {usage: "check the format of addresses, exits non zero if one is invalid", run: runAddressesVerify},
+	}, args)
+}
+
+func runAddressesApply(args []string) error {
+	fs, api := newAPIFlagSet("addresses apply")
+	walletCode := fs.String("wallet", "", "wallet code (required)")
+	coin := fs.String("coin", "", "coin of the addresses, optional")
+	number := fs.Int("n", 1, "number of addresses to apply for")
+	addressType := fs.String("type", "NORMAL_ADDRESS", "address type")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "wallet"); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.ApplyNewAddressWithContext(ctx, bId, *walletCode, constants.CactusToken(*coin), *number, *addressType)
+	if err != nil {
+		return err
+	}
+	t := newTable("ADDRESS")
+	for _, address := range resp.Data {
+		t.add(address)
+	}
+	return api.print(resp.Data, t)
+}
+
+func runAddressesList(args []string) error {
+	fs, api := newAPIFlagSet("addresses list")
+	page := addPageFlags(fs)
+	walletCode := fs.String("wallet", "", "wallet code (required)")
+	coin := fs.String("coin", "", "only addresses of this coin")
+	keyword := fs.String("keyword", "", "search addresses and descriptions")
+	hideEmpty := fs.Bool("hide-empty", false, "hide addresses without coins")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "wallet"); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	coinName := constants.CactusToken(*coin)
+	addresses, err := fetchList(page, func(offset int, limit int) (*cactus.GetAddressListResp, error) {
+		return client.GetAddressListWithContext(ctx, bId, *walletCode, coinName, *hideEmpty, *keyword, offset, limit)
+	}, func(pageSize int) iter.Seq2[cactus.Address, error] {
+		return client.IterAddressList(ctx, bId, *walletCode, coinName, *hideEmpty, *keyword, pageSize)
+	})
+	if err != nil {
+		return err
+	}
+	t := newTable("ADDRESS", "COIN", "TYPE", "STORAGE", "AVAILABLE", "FREEZE", "TOTAL", "DESCRIPTION")
+	for _, a := range addresses {
+		t.add(a.Address, a.CoinName, a.AddressType, a.AddressStorage,
+			a.AvailableAmount.String(), a.FreezeAmount.String(), a.TotalAmount.String(), a.Description)
+	}
+	return api.print(addresses, t)
+}
+
+func runAddressesDescribe(args []string) error {
+	fs, api := newAPIFlagSet("addresses describe")
+	walletCode := fs.String("wallet", "", "wallet code (required)")
+	address := fs.String("address", "", "address (required)")
+	description := fs.String("description", "", "new description, empty clears it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "wallet", "address"); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	if _, err = client.EditAddressDescriptionWithContext(ctx, bId, *walletCode, *address, *description); err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "updated the description of %s\n", *address)
+	return nil
+}
+
+func runAddressesVerify(args []string) error {
+	fs, api := newAPIFlagSet("addresses verify")
+	coin := fs.String("coin", "", "coin the addresses are for (required)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: cactus addresses verify -coin <coin> [flags] address...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "coin"); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("no addresses given")
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.VerifyAddressWithContext(ctx, constants.CactusToken(*coin), fs.Args())
+	if err != nil {
+		return err
+	}
+	invalid := map[string]bool{}
+	for _, address := range resp.Data {
+		invalid[address] = true
+	}
+	t := newTable("ADDRESS", "VALID")
+	for _, address := range fs.Args() {
+		t.add(address, strconv.FormatBool(!invalid[address]))
+	}
+	if err = api.print(resp.Data, t); err != nil {
+		return err
+	}
+	if len(resp.Data) > 0 {
+		return fmt.Errorf("%d of %d addresses are invalid", len(resp.Data), fs.NArg())
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/config"
+	"io"
+	"iter"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// apiFlags are the flags shared by every command calling the api
+type apiFlags struct {
+	config  string
+	profile string
+	bId     string
+	output  string
+	verbose bool
+}
+
+// newAPIFlagSet creates the flag set of a command calling the api
+func newAPIFlagSet(name string) (*flag.FlagSet, *apiFlags) {
+	fs := newFlagSet(name)
+	f := &apiFlags{}
+	fs.StringVar(&f.config, "config", "", "profile file, yaml or json, defaults to $"+config.EnvConfig+", the CACTUS_* variables are used without one")
+	fs.StringVar(&f.profile, "profile", "", "profile to use, defaults to $"+config.EnvProfile+" or the default of the profile file")
+	fs.StringVar(&f.bId, "b", "", "business id, defaults to the b_id of the profile")
+	fs.StringVar(&f.output, "o", "table", "output format: table, json or csv")
+	fs.BoolVar(&f.verbose, "v", false, "log every request to stderr")
+	return fs, f
+}
+
+// client creates the api client from the selected profile and resolves the business id
+func (f *apiFlags) client() (*cactus.Cactus, error) {
+	switch f.output {
+	case "table", "json", "csv":
+	default:
+		return nil, fmt.Errorf("unknown output format %q", f.output)
+	}
+	p, err := config.LoadProfile(f.config, f.profile)
+	if err != nil {
+		return nil, err
+	}
+	if f.bId == "" {
+		f.bId = p.BId
+	}
+	client, err := p.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	client.Logger = cactus.NopLogger{}
+	if f.verbose {
+		client.Logger = cactus.NewSlogLogger(slog.New(slog.NewTextHandler(stderr, nil)))
+	}
+	return client, nil
+}
+
+// business returns the business id, for the commands that need one
+func (f *apiFlags) business() (string, error) {
+	if f.bId == "" {
+		return "", errors.New("business id is not set, pass -b or set b_id in the profile")
+	}
+	return f.bId, nil
+}
+
+// table is the tabular form of a result, printed by -o table and -o csv
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func newTable(header ...string) *table {
+	return &table{header: header}
+}
+
+func (t *table) add(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// print writes data as indented json, or t as an aligned table or csv
+func (f *apiFlags) print(data any, t *table) error {
+	switch f.output {
+	case "json":
+		out, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = stdout.Write(append(out, '\n'))
+		return err
+	case "csv":
+		w := csv.NewWriter(stdout)
+		if err := w.Write(t.header); err != nil {
+			return err
+		}
+		return w.WriteAll(t.rows)
+	}
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, row := range append([][]string{t.header}, t.rows...) {
+		for i, cell := range row {
+			row[i] = strings.ReplaceAll(cell, "\t", " ")
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// commandContext is cancelled by an interrupt, so a long listing can be stopped cleanly
+func commandContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt)
+}
+
+// requireFlags reports the first of the named flags of fs left empty
+func requireFlags(fs *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if f := fs.Lookup(name); f != nil && f.Value.String() == "" {
+			fs.Usage()
+			return fmt.Errorf("-%s is required", name)
+		}
+	}
+	return nil
+}
+
+// readBody decodes the json request body in file into v, "-" reads stdin
+// Unknown fields are rejected, a misspelled field would otherwise be silently dropped.
+func readBody(file string, v any) error {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(v); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	return nil
+}
+
+// readBodyFlag reads the request body of -body, which can not be combined with the flags building one
+func readBodyFlag(fs *flag.FlagSet, file string, v any) error {
+	var mixed []string
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "body", "config", "profile", "b", "o", "v":
+		default:
+			mixed = append(mixed, "-"+f.Name)
+		}
+	})
+	if len(mixed) > 0 {
+		return fmt.Errorf("-body can not be combined with %s", strings.Join(mixed, ", "))
+	}
+	return readBody(file, v)
+}
+
+// pageFlags select the page printed by a list command, -all walks every page
+type pageFlags struct {
+	offset int
+	limit  int
+	all    bool
+}
+
+func addPageFlags(fs *flag.FlagSet) *pageFlags {
+	p := &pageFlags{}
+	fs.IntVar(&p.offset, "offset", 0, "offset of the first item")
+	fs.IntVar(&p.limit, "limit", 0, "page size, the cactus default when 0")
+	fs.BoolVar(&p.all, "all", false, "list every item, fetching -limit items per request")
+	return p
+}
+
+// fetchList returns the page selected by p with fetch, or with -all every item of iterate
+func fetchList[T any](p *pageFlags,
+	fetch func(offset int, limit int) (*cactus.Response[cactus.Page[T]], error),
+	iterate func(pageSize int) iter.Seq2[T, error]) ([]T, error) {
+	if !p.all {
+		resp, err := fetch(p.offset, p.limit)
+		if err != nil {
+			return nil, err
+		}
+		if resp.Data.List == nil {
+			return []T{}, nil
+		}
+		return resp.Data.List, nil
+	}
+	if p.offset != 0 {
+		return nil, errors.New("-offset and -all are mutually exclusive")
+	}
+	list := []T{}
+	for item, err := range iterate(p.limit) {
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+// listFlag is a repeatable flag, each value may also hold several comma separated items
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// typed converts the items of a listFlag to one of the constants string types
+func typed[T ~string](l listFlag) []T {
+	out := make([]T, len(l))
+	for i, item := range l {
+		out[i] = T(item)
+	}
+	return out
+}
+
+// timeFlag is a point in time given as RFC 3339, a date, or unix milliseconds
+type timeFlag struct {
+	time.Time
+}
+
+func (t *timeFlag) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (t *timeFlag) Set(value string) error {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		t.Time = time.UnixMilli(ms)
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateTime, time.DateOnly} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid time %q, want RFC 3339, 2006-01-02 or unix milliseconds", value)
+}
+
+// amountFlag is an integer amount in base units, e.g. wei
+type amountFlag struct {
+	*cactus.Amount
+}
+
+func (a amountFlag) String() string {
+	if a.Amount == nil {
+		return "0"
+	}
+	return a.Amount.String()
+}
+
+func (a amountFlag) Set(value string) error {
+	amount, err := cactus.ParseAmount(value)
+	if err != nil {
+		return err
+	}
+	*a.Amount = amount
+	return nil
+}
+
+// sortFlag maps -asc onto the sort order of a list, descending is the cactus default
+func sortFlag(asc bool) cactus.SortOrder {
+	if asc {
+		return cactus.SortAsc
+	}
+	return cactus.SortUnset
+}
+
+// formatTime formats a millisecond timestamp of cactus, 0 is left empty
+func formatTime(ms int64) string {
+	if ms == 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactustest"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/config"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/keys"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newProfile starts a fake server and writes a profile file for it
+func newProfile(t *testing.T) (*cactustest.Server, string) {
+	t.Helper()
+	srv := cactustest.NewServer()
+	t.Cleanup(srv.Close)
+	dir := t.TempDir()
+	if err := keys.WritePrivateKeyFile(filepath.Join(dir, "key.pem"), srv.PrivateKey, nil); err != nil {
+		t.Fatal(err)
+	}
+	profiles := `profiles:
+  test:
+    base_uri: ` + srv.URL + `
+    api_key: ` + srv.APIKey + `
+    api_key_id: ` + srv.KeyID + `
+    key:
+      file: key.pem
+    b_id: ` + cactustest.DefaultBId + `
+`
+	path := filepath.Join(dir, "profiles.yaml")
+	if err := os.WriteFile(path, []byte(profiles), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{config.EnvProfile, config.EnvBId, cactus.EnvBaseUri, cactus.EnvApiKey, cactus.EnvApiKeyID,
+		cactus.EnvPrivateKeyFile, cactus.EnvPrivateKeyPassphrase} {
+		t.Setenv(key, "")
+	}
+	t.Setenv(config.EnvConfig, path)
+	return srv, dir
+}
+
+func runError(args ...string) (string, error) {
+	var out bytes.Buffer
+	stdout, stderr = &out, &bytes.Buffer{}
+	err := run(args)
+	return out.String(), err
+}
+
+func TestWalletsAndAddresses(t *testing.T) {
+	srv, _ := newProfile(t)
+	w := srv.AddWallet(cactustest.Wallet{CoinName: "ETH"})
+
+	listed := runCapture(t, "wallets", "list", "-o", "csv")
+	if lines := strings.Split(strings.TrimSpace(listed), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[1], w.Code+",") {
+		t.Errorf("wallets list -o csv = %q, want a header and %s", listed, w.Code)
+	}
+	var applied []string
+	if err := json.Unmarshal([]byte(runCapture(t, "addresses", "apply", "-wallet", w.Code, "-coin", "ETH", "-n", "2", "-o", "json")), &applied); err != nil || len(applied) != 2 {
+		t.Fatalf("addresses apply = %v, %v, want 2 addresses", applied, err)
+	}
+	runCapture(t, "addresses", "describe", "-wallet", w.Code, "-address", applied[0], "-description", "hot\tspare")
+	table := runCapture(t, "addresses", "list", "-wallet", w.Code, "-all", "-limit", "1")
+	if !strings.Contains(table, applied[1]) || !strings.Contains(table, "hot spare") {
+		t.Errorf("addresses list = %q, want both addresses and the description", table)
+	}
+
+	out, err := runError("addresses", "verify", "-coin", "ETH", applied[0], "not-an-address")
+	if err == nil || !strings.Contains(strings.Join(strings.Fields(out), " "), "not-an-address false") {
+		t.Errorf("addresses verify = %q, %v, want the invalid address reported and an error", out, err)
+	}
+}
+
+func TestWithdrawFromBodyAndFlags(t *testing.T) {
+	srv, dir := newProfile(t)
+	w := srv.AddWallet(cactustest.Wallet{CoinName: "ETH", Balances: map[string]int64{"ETH": 1000}})
+	dest := "0x00000000000000000000000000000000000000aa"
+	body := filepath.Join(dir, "withdraw.json")
+	err := os.WriteFile(body, []byte(`{"from_wallet_code":"`+w.Code+`","coin_name":"ETH","order_no":"order-1",
+		"dest_address_item_list":{"amount":400,"dest_address":"`+dest+`"}}`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out := runCapture(t, "withdraw", "create", "-body", body); !strings.Contains(out, "order-1") {
+		t.Errorf("withdraw create -body = %q, want order-1", out)
+	}
+	// 1e-16 ETH is 100 wei
+	runCapture(t, "withdraw", "create", "-wallet", w.Code, "-coin", "ETH", "-to", dest, "-amount", "0.0000000000000001", "-order-no", "order-2")
+	if _, err = runError("withdraw", "create", "-body", body, "-coin", "ETH"); err == nil {
+		t.Error("withdraw create with -body and -coin succeeded, want an error")
+	}
+	if _, err = runError("withdraw", "estimate", "-wallet", w.Code, "-coin", "ETH", "-to", dest, "-amount", "1e-18"); err == nil {
+		t.Error("withdraw estimate -amount 1e-18 succeeded, want an invalid amount")
+	}
+
+	var orders []cactus.Order
+	if err = json.Unmarshal([]byte(runCapture(t, "orders", "list", "-all", "-o", "json")), &orders); err != nil {
+		t.Fatal(err)
+	}
+	amounts := map[string]string{}
+	for _, o := range orders {
+		amounts[o.OrderNo] = o.OriginalAmount.String()
+	}
+	if amounts["order-1"] != "400" || amounts["order-2"] != "100" {
+		t.Errorf("orders list amounts = %v, want order-1 400 and order-2 100", amounts)
+	}
+}
+
+func TestOutputFormatAndBusinessId(t *testing.T) {
+	srv, _ := newProfile(t)
+	if _, err := runError("chains", "-o", "yaml"); err == nil || !strings.Contains(err.Error(), "yaml") {
+		t.Errorf("chains -o yaml error = %v, want an unknown format", err)
+	}
+	chains := runCapture(t, "chains")
+	if !strings.HasPrefix(chains, "CHAIN ") || !strings.Contains(chains, "ETH") {
+		t.Errorf("chains = %q, want a table listing ETH", chains)
+	}
+	srv.AddWallet(cactustest.Wallet{CoinName: "ETH", Balances: map[string]int64{"ETH": 10}})
+	if out := runCapture(t, "wallets", "list", "-b", "other-b-id", "-o", "json"); strings.TrimSpace(out) != "[]" {
+		t.Errorf("wallets list -b other-b-id = %q, want no wallets of the profile b_id", out)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+func runAssets(args []string) error {
+	return subcommands("assets", map[string]command{
+		"current": {usage: "current notional value of the assets, per coin", run: runAssetsCurrent},
+		"history": {usage: "notional value of the assets over time", run: runAssetsHistory},
+	}, args)
+}
+
+func runAssetsCurrent(args []string) error {
+	fs, api := newAPIFlagSet("assets current")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.GetCurrentAssetNotionalValueWithContext(ctx, api.bId)
+	if err != nil {
+		return err
+	}
+	v := resp.Data
+	t := newTable("COIN", "STORAGE", "AMOUNT", "USD", "CNY")
+	for _, coin := range v.Coins {
+		t.add(coin.CoinName, coin.StoreType, coin.Amount.String(), formatFloat(coin.Value), formatFloat(coin.ValueCny))
+	}
+	t.add("HOT", "", "", formatFloat(v.HotMarketValue), formatFloat(v.HotMarketValueCny))
+	t.add("COLD", "", "", formatFloat(v.ColdMarketValue), formatFloat(v.ColdMarketValueCny))
+	t.add("TOTAL", "", "", formatFloat(v.TotalMarketValue), formatFloat(v.TotalMarketValueCny))
+	return api.print(v, t)
+}
+
+func runAssetsHistory(args []string) error {
+	fs, api := newAPIFlagSet("assets history")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.GetTotalAssetNotionalValueWithContext(ctx, api.bId)
+	if err != nil {
+		return err
+	}
+	t := newTable("TIME", "USD", "CNY")
+	for _, snapshot := range resp.Data.HistoryAssetResult {
+		t.add(snapshot.CreateTime, snapshot.MarketValue, snapshot.MarketValueCny)
+	}
+	return api.print(resp.Data, t)
+}
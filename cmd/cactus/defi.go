@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"strconv"
+)
+
+func runContract(args []string) error {
+	return subcommands("contract", map[string]command{
+		"call": {usage: "create a contract call order", run: runContractCall},
+		"show": {usage: "show a contract or signature order", run: runContractShow},
+	}, args)
+}
+
+func runContractCall(args []string) error {
+	fs, api := newAPIFlagSet("contract call")
+	body := fs.String("body", "", "json request body file, - reads stdin, replaces the request flags")
+	var req cactus.CreateContractOrderReq
+	fs.StringVar(&req.FromWalletCode, "wallet", "", "defi wallet code")
+	fs.StringVar(&req.FromAddress, "from", "", "address of the wallet sending the call")
+	fs.StringVar(&req.ToAddress, "to", "", "contract address")
+	chain := fs.String("chain", "", "chain of the contract")
+	fs.StringVar(&req.ContractData, "data", "", "hex encoded call data")
+	fs.Var(amountFlag{&req.Amount}, "value", "native coin sent with the call, in base units")
+	fs.IntVar(&req.GasLimit, "gas-limit", 0, "gas limit")
+	fs.StringVar(&req.GasPriceLevel, "gas-level", "", "gas price level, instead of explicit prices")
+	fs.Var(amountFlag{&req.GasPrice}, "gas-price", "legacy gas price in wei")
+	fs.Var(amountFlag{&req.MaxFeePerGas}, "max-fee", "EIP-1559 max fee per gas in wei")
+	fs.Var(amountFlag{&req.MaxPriorityFeePerGas}, "max-priority-fee", "EIP-1559 max priority fee per gas in wei")
+	fs.StringVar(&req.OrderNo, "order-no", "", "client order number, makes retries safe")
+	fs.StringVar(&req.Description, "description", "", "order description")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *body != "" {
+		req = cactus.CreateContractOrderReq{}
+		if err := readBodyFlag(fs, *body, &req); err != nil {
+			return err
+		}
+	} else {
+		if err := requireFlags(fs, "wallet", "from", "to", "chain", "data"); err != nil {
+			return err
+		}
+		req.Chain = constants.ChainName(*chain)
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.CreateContractOrderWithContext(ctx, bId, req)
+	if err != nil {
+		return err
+	}
+	return printCreatedOrder(api, resp.Data)
+}
+
+func runContractShow(args []string) error {
+	fs, api := newAPIFlagSet("contract show")
+	walletCode := fs.String("wallet", "", "defi wallet code (required)")
+	orderNo := fs.String("order", "", "order number (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "wallet", "order"); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.GetDefiTransactionDetailsWithContext(ctx, bId, *walletCode, *orderNo)
+	if err != nil {
+		return err
+	}
+	o := resp.Data
+	t := newTable("ORDER_NO", "TIME", "STATUS", "CONTRACT", "AMOUNT", "GAS_LIMIT", "MINER_FEE", "TX_ID")
+	t.add(o.OrderNo, formatTime(o.TimeStamp), o.Status, o.ContractAddress, o.Amount.String(),
+		strconv.Itoa(o.GasLimit), o.MinerFee.String(), o.TxId)
+	return api.print(o, t)
+}
+
+func runSign(args []string) error {
+	fs, api := newAPIFlagSet("sign")
+	walletCode := fs.String("wallet", "", "defi wallet code (required)")
+	body := fs.String("body", "", "json request body file with address, chain, signature_version and payload, - reads stdin (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "wallet", "body"); err != nil {
+		return err
+	}
+	var req cactus.CreateSignOrderReq
+	if err := readBody(*body, &req); err != nil {
+		return err
+	}
+	if req.Address == "" || req.Payload == nil {
+		return errors.New("the body needs an address and a payload")
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.CreateSignOrderWithContext(ctx, bId, *walletCode, req)
+	if err != nil {
+		return err
+	}
+	return printCreatedOrder(api, resp.Data)
+}
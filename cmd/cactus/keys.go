@@ -0,0 +1,157 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/keys"
+	"os"
+	"strings"
+)
+
+func runKeys(args []string) error {
+	return subcommands("keys", map[string]command{
+		"generate":    {usage: "generate a P-256 api key pair", run: runKeysGenerate},
+		"public":      {usage: "export the public key to register with cactus", run: runKeysPublic},
+		"fingerprint": {usage: "print the fingerprint of a private or public key", run: runKeysFingerprint},
+	}, args)
+}
+
+// readPassphrase reads the passphrase from file, falling back to CACTUS_PRIVATE_KEY_PASSPHRASE
+func readPassphrase(file string) ([]byte, error) {
+	if file == "" {
+		return []byte(os.Getenv(cactus.EnvPrivateKeyPassphrase)), nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimRight(string(data), "\r\n")), nil
+}
+
+func runKeysGenerate(args []string) error {
+	fs := newFlagSet("keys generate")
+	out := fs.String("out", "", "private key file to create, PKCS#8 PEM (required)")
+	pubOut := fs.String("pub", "", "also write the public key PEM to this file")
+	passphraseFile := fs.String("passphrase-file", "", "encrypt the key with the passphrase in this file, defaults to $"+cactus.EnvPrivateKeyPassphrase)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		fs.Usage()
+		return errors.New("-out is required")
+	}
+	passphrase, err := readPassphrase(*passphraseFile)
+	if err != nil {
+		return err
+	}
+	key, err := keys.GenerateKey()
+	if err != nil {
+		return err
+	}
+	if err = keys.WritePrivateKeyFile(*out, key, passphrase); err != nil {
+		return err
+	}
+	pubPEM, err := keys.EncodePublicKeyPEM(&key.PublicKey)
+	if err != nil {
+		return err
+	}
+	if *pubOut != "" {
+		if err = os.WriteFile(*pubOut, pubPEM, 0644); err != nil {
+			return err
+		}
+	}
+	fingerprint, err := keys.Fingerprint(&key.PublicKey)
+	if err != nil {
+		return err
+	}
+	encrypted := "unencrypted"
+	if len(passphrase) > 0 {
+		encrypted = "encrypted"
+	}
+	fmt.Fprintf(stderr, "wrote %s private key to %s\n", encrypted, *out)
+	fmt.Fprintf(stdout, "%sfingerprint: %s\n", pubPEM, fingerprint)
+	return nil
+}
+
+// loadPublicKey reads the public key from a private key file or a public key PEM
+func loadPublicKey(keyFile string, pubFile string, passphraseFile string) (*ecdsa.PublicKey, error) {
+	switch {
+	case keyFile != "" && pubFile != "":
+		return nil, errors.New("-key and -pub are mutually exclusive")
+	case pubFile != "":
+		data, err := os.ReadFile(pubFile)
+		if err != nil {
+			return nil, err
+		}
+		return keys.ParsePublicKeyPEM(data)
+	case keyFile != "":
+		passphrase, err := readPassphrase(passphraseFile)
+		if err != nil {
+			return nil, err
+		}
+		key, err := keys.LoadPrivateKeyFile(keyFile, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return &key.PublicKey, nil
+	}
+	return nil, errors.New("-key or -pub is required")
+}
+
+func runKeysPublic(args []string) error {
+	fs := newFlagSet("keys public")
+	keyFile := fs.String("key", "", "private key PEM file")
+	passphraseFile := fs.String("passphrase-file", "", "passphrase of an encrypted key, defaults to $"+cactus.EnvPrivateKeyPassphrase)
+	format := fs.String("format", "pem", "output format: pem, base64 (DER on one line) or der (binary)")
+	out := fs.String("out", "", "write to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	pub, err := loadPublicKey(*keyFile, "", *passphraseFile)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	switch *format {
+	case "pem":
+		data, err = keys.EncodePublicKeyPEM(pub)
+	case "der":
+		data, err = keys.MarshalPublicKeyDER(pub)
+	case "base64":
+		var encoded string
+		encoded, err = keys.EncodePublicKeyBase64(pub)
+		data = []byte(encoded + "\n")
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		return err
+	}
+	if *out != "" {
+		return os.WriteFile(*out, data, 0644)
+	}
+	_, err = stdout.Write(data)
+	return err
+}
+
+func runKeysFingerprint(args []string) error {
+	fs := newFlagSet("keys fingerprint")
+	keyFile := fs.String("key", "", "private key PEM file")
+	pubFile := fs.String("pub", "", "public key PEM file")
+	passphraseFile := fs.String("passphrase-file", "", "passphrase of an encrypted key, defaults to $"+cactus.EnvPrivateKeyPassphrase)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	pub, err := loadPublicKey(*keyFile, *pubFile, *passphraseFile)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := keys.Fingerprint(pub)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, fingerprint)
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runCapture(t *testing.T, args ...string) string {
+	t.Helper()
+	var out bytes.Buffer
+	stdout, stderr = &out, &bytes.Buffer{}
+	if err := run(args); err != nil {
+		t.Fatalf("cactus %s: %v", strings.Join(args, " "), err)
+	}
+	return out.String()
+}
+
+func TestKeysGenerateAndFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key.pem")
+	pubFile := filepath.Join(dir, "key.pub")
+	t.Setenv("CACTUS_PRIVATE_KEY_PASSPHRASE", "secret")
+	generated := runCapture(t, "keys", "generate", "-out", keyFile, "-pub", pubFile)
+	fromKey := runCapture(t, "keys", "fingerprint", "-key", keyFile)
+	fromPub := runCapture(t, "keys", "fingerprint", "-pub", pubFile)
+	if fromKey != fromPub || !strings.Contains(generated, "fingerprint: "+fromKey) {
+		t.Errorf("fingerprints differ: generate %q, key %q, pub %q", generated, fromKey, fromPub)
+	}
+	if pub := runCapture(t, "keys", "public", "-key", keyFile); !strings.HasPrefix(generated, pub) {
+		t.Errorf("keys public = %q, want the generated public key", pub)
+	}
+}
@@ -0,0 +1,106 @@
+// Command cactus is a command line client for the cactus custody api
+//
+// The api commands create their client from a profile of the file given by -config or $CACTUS_CONFIG,
+// selected with -profile, see package config, or from the CACTUS_* environment variables without one.
+// Results are printed as a table, json or csv with -o, and the commands creating orders read their
+// request body from a json file with -body.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// command is a top level subcommand, run gets the arguments following the subcommand name
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"keys":      {usage: "generate, export and fingerprint api keys", run: runKeys},
+	"wallets":   {usage: "list, show and create wallets", run: runWallets},
+	"addresses": {usage: "apply, list, describe and verify addresses", run: runAddresses},
+	"orders":    {usage: "list, show, accelerate and cancel withdrawal orders", run: runOrders},
+	"withdraw":  {usage: "estimate the fee of and create withdrawals", run: runWithdraw},
+	"tx":        {usage: "wallet transaction summaries, details and remarks", run: runTx},
+	"contract":  {usage: "call contracts and show contract orders", run: runContract},
+	"sign":      {usage: "create a signature order", run: runSign},
+	"assets":    {usage: "current and historical asset notional value", run: runAssets},
+	"coins":     {usage: "list the coins supported by cactus", run: runCoins},
+	"chains":    {usage: "list the chains supported by cactus", run: runChains},
+}
+
+var (
+	stdin  io.Reader = os.Stdin
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(stderr, "cactus:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "help" {
+		printUsage()
+		return flag.ErrHelp
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		printUsage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return cmd.run(args[1:])
+}
+
+func printUsage() {
+	fmt.Fprintln(stderr, "usage: cactus <command> [arguments]")
+	fmt.Fprintln(stderr, "commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(stderr, "  %-12s %s\n", name, commands[name].usage)
+	}
+}
+
+// subcommands dispatches args[0] to one of subs, used for "cactus <command> <subcommand>"
+func subcommands(name string, subs map[string]command, args []string) error {
+	if len(args) > 0 {
+		if sub, ok := subs[args[0]]; ok {
+			return sub.run(args[1:])
+		}
+	}
+	fmt.Fprintf(stderr, "usage: cactus %s <subcommand> [arguments]\nsubcommands:\n", name)
+	names := make([]string, 0, len(subs))
+	for sub := range subs {
+		names = append(names, sub)
+	}
+	sort.Strings(names)
+	for _, sub := range names {
+		fmt.Fprintf(stderr, "  %-12s %s\n", sub, subs[sub].usage)
+	}
+	if len(args) == 0 {
+		return flag.ErrHelp
+	}
+	return fmt.Errorf("unknown %s subcommand %q", name, args[0])
+}
+
+// newFlagSet creates a flag set reporting errors instead of exiting
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("cactus "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
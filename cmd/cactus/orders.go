@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+func runOrders(args []string) error {
+	return subcommands("orders", map[string]command{
+		"list":       {usage: "list withdrawal orders", run: runOrdersList},
+		"show":       {usage: "show an order and its transactions", run: runOrdersShow},
+		"accelerate": {usage: "replace the transaction of an order with a higher fee", run: runOrdersAccelerate},
+		"cancel":     {usage: "cancel a pending order by replacing its transaction", run: runOrdersCancel},
+	}, args)
+}
+
+func runOrdersList(args []string) error {
+	fs, api := newAPIFlagSet("orders list")
+	page := addPageFlags(fs)
+	var applicants, coins, chains, walletNames, statuses listFlag
+	var start, end timeFlag
+	fs.Var(&applicants, "applicant", "only orders of this applicant, repeatable")
+	fs.Var(&coins, "coin", "only orders of this coin, repeatable")
+	fs.Var(&chains, "chain", "only orders on this chain, repeatable")
+	fs.Var(&walletNames, "wallet-name", "only orders from this wallet, repeatable")
+	fs.Var(&statuses, "status", "only orders in this status, repeatable")
+	fs.Var(&start, "start", "only orders created at or after this time")
+	fs.Var(&end, "end", "only orders created before this time")
+	keyword := fs.String("keyword", "", "search order numbers and descriptions")
+	asc := fs.Bool("asc", false, "oldest first")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	filter := cactus.OrderFilter{
+		Applicants:  applicants,
+		CoinNames:   typed[constants.CactusToken](coins),
+		Chains:      typed[constants.ChainName](chains),
+		WalletNames: walletNames,
+		Statuses:    statuses,
+		Keyword:     *keyword,
+		SortByTime:  sortFlag(*asc),
+		Start:       start.Time,
+		End:         end.Time,
+	}
+	orders, err := fetchList(page, func(offset int, limit int) (*cactus.GetFilteredOrderResp, error) {
+		return client.ListOrdersWithContext(ctx, bId, filter, offset, limit)
+	}, func(pageSize int) iter.Seq2[cactus.Order, error] {
+		return client.IterFilteredOrder(ctx, bId, filter, pageSize)
+	})
+	if err != nil {
+		return err
+	}
+	t := newTable("ORDER_NO", "TIME", "WALLET", "COIN", "AMOUNT", "STATUS", "APPLICANT")
+	for _, o := range orders {
+		t.add(o.OrderNo, formatTime(o.TimeStamp), o.WalletName, o.CoinName, o.OriginalAmount.String(), o.Status, o.Applicant)
+	}
+	return api.print(orders, t)
+}
+
+func runOrdersShow(args []string) error {
+	fs, api := newAPIFlagSet("orders show")
+	orderNo := fs.String("order", "", "order number (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "order"); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.GetOrderDetailsWithContext(ctx, bId, *orderNo)
+	if err != nil {
+		return err
+	}
+	o := resp.Data.OrderWalletInfo
+	hashes := make([]string, len(resp.Data.TxInfoModels))
+	for i, tx := range resp.Data.TxInfoModels {
+		hashes[i] = tx.TxHash
+	}
+	t := newTable("ORDER_NO", "TIME", "WALLET", "COIN", "AMOUNT", "MINER_FEE", "STATUS", "FROM", "TX_HASHES")
+	t.add(o.OrderNo, formatTime(o.Timestamp), o.WalletCode, o.CoinName, o.Amount.String(), o.MinerFee.String(),
+		o.Status, o.FromAddress, strings.Join(hashes, " "))
+	return api.print(resp.Data, t)
+}
+
+func runOrdersAccelerate(args []string) error {
+	return runReplaceByFee("accelerate", args, (*cactus.Cactus).ReplaceByFeeWithContext)
+}
+
+func runOrdersCancel(args []string) error {
+	return runReplaceByFee("cancel", args, (*cactus.Cactus).CancelOrderWithContext)
+}
+
+// runReplaceByFee runs accelerate and cancel, which only differ in the endpoint they call
+func runReplaceByFee(name string, args []string, replace func(*cactus.Cactus, context.Context, string, string, constants.ReplaceByFeeLevel, float64) (*cactus.Response[int64], error)) error {
+	fs, api := newAPIFlagSet("orders " + name)
+	orderNo := fs.String("order", "", "order number (required)")
+	level := fs.String("level", string(constants.ReplaceByFeeLevel1), "fee level: LEVEL1, LEVEL2, LEVEL3 or CUSTOM")
+	gasPrice := fs.Float64("gas-price", 0, "gas price of the CUSTOM level")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "order"); err != nil {
+		return err
+	}
+	if constants.ReplaceByFeeLevel(*level) == constants.ReplaceByFeeCustom && *gasPrice <= 0 {
+		return errors.New("-gas-price is required with -level CUSTOM")
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := replace(client, ctx, bId, *orderNo, constants.ReplaceByFeeLevel(*level), *gasPrice)
+	if err != nil {
+		return err
+	}
+	t := newTable("ORDER_NO", "RESULT")
+	t.add(*orderNo, strconv.FormatInt(resp.Data, 10))
+	return api.print(resp.Data, t)
+}
+
+func runWithdraw(args []string) error {
+	return subcommands("withdraw", map[string]command{
+		"estimate": {usage: "estimate the miner fee of a withdrawal", run: runWithdrawEstimate},
+		"create":   {usage: "create a withdrawal order", run: runWithdrawCreate},
+	}, args)
+}
+
+// withdrawalFlags build a withdrawal request from flags, or read it from -body
+type withdrawalFlags struct {
+	body        string
+	wallet      string
+	from        string
+	coin        string
+	to          string
+	amount      string
+	allBalance  bool
+	memoType    string
+	memo        string
+	feeLevel    string
+	feeRate     float64
+	orderNo     string
+	description string
+	remark      string
+}
+
+func addWithdrawalFlags(fs *flag.FlagSet) *withdrawalFlags {
+	w := &withdrawalFlags{}
+	fs.StringVar(&w.body, "body", "", "json request body file, - reads stdin, replaces the request flags")
+	fs.StringVar(&w.wallet, "wallet", "", "wallet code to withdraw from")
+	fs.StringVar(&w.from, "from", "", "address to withdraw from, optional")
+	fs.StringVar(&w.coin, "coin", "", "coin to withdraw")
+	fs.StringVar(&w.to, "to", "", "destination address")
+	fs.StringVar(&w.amount, "amount", "", "amount in whole coins, e.g. 1.5, converted with the decimals of -coin")
+	fs.BoolVar(&w.allBalance, "all-balance", false, "withdraw the whole balance")
+	fs.StringVar(&w.memoType, "memo-type", "", "memo type of the destination")
+	fs.StringVar(&w.memo, "memo", "", "memo of the destination")
+	fs.StringVar(&w.feeLevel, "fee-level", "", "LOW, NORMAL, HIGHER or CUSTOM")
+	fs.Float64Var(&w.feeRate, "fee-rate", 0, "fee rate of the CUSTOM level")
+	fs.StringVar(&w.orderNo, "order-no", "", "client order number, makes retries safe")
+	fs.StringVar(&w.description, "description", "", "order description")
+	fs.StringVar(&w.remark, "remark", "", "destination remark")
+	return w
+}
+
+// request returns the withdrawal request, -amount is converted with the coin decimals known to cactus
+func (w *withdrawalFlags) request(ctx context.Context, fs *flag.FlagSet, client *cactus.Cactus) (cactus.WithdrawalArgsFeeReq, error) {
+	var req cactus.WithdrawalArgsFeeReq
+	if w.body != "" {
+		err := readBodyFlag(fs, w.body, &req)
+		return req, err
+	}
+	if err := requireFlags(fs, "wallet", "coin", "to"); err != nil {
+		return req, err
+	}
+	var amount cactus.Amount
+	switch {
+	case w.amount != "" && w.allBalance:
+		return req, errors.New("-amount and -all-balance are mutually exclusive")
+	case w.amount != "":
+		var err error
+		if amount, err = client.Registry().Converter().Parse(ctx, constants.CactusToken(w.coin), w.amount); err != nil {
+			return req, err
+		}
+	case !w.allBalance:
+		return req, errors.New("-amount or -all-balance is required")
+	}
+	return cactus.WithdrawalArgsFeeReq{
+		FromAddress:    w.from,
+		FromWalletCode: w.wallet,
+		CoinName:       constants.CactusToken(w.coin),
+		OrderNo:        w.orderNo,
+		Description:    w.description,
+		FeeRateLevel:   constants.FeeLevelType(w.feeLevel),
+		FeeRate:        w.feeRate,
+		DestAddressItemList: cactus.DestAddressItem{
+			Amount:          amount,
+			DestAddress:     w.to,
+			MemoType:        w.memoType,
+			Memo:            constants.MemoType(w.memo),
+			IsAllWithdrawal: w.allBalance,
+			Remark:          w.remark,
+		},
+	}, nil
+}
+
+func runWithdrawEstimate(args []string) error {
+	fs, api := newAPIFlagSet("withdraw estimate")
+	w := addWithdrawalFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	req, err := w.request(ctx, fs, client)
+	if err != nil {
+		return err
+	}
+	resp, err := client.EstimateWithdrawalFeeWithContext(ctx, bId, req)
+	if err != nil {
+		return err
+	}
+	t := newTable("COIN", "MINER_FEE")
+	t.add(string(req.CoinName), resp.Data.String())
+	return api.print(resp.Data, t)
+}
+
+func runWithdrawCreate(args []string) error {
+	fs, api := newAPIFlagSet("withdraw create")
+	w := addWithdrawalFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	req, err := w.request(ctx, fs, client)
+	if err != nil {
+		return err
+	}
+	resp, err := client.CreateWithdrawOrderWithContext(ctx, bId, req)
+	if err != nil {
+		return err
+	}
+	return printCreatedOrder(api, resp.Data)
+}
+
+func printCreatedOrder(api *apiFlags, order cactus.CreatedOrder) error {
+	t := newTable("ORDER_NO")
+	t.add(order.OrderNo)
+	return api.print(order, t)
+}
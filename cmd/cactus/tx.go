@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"iter"
+	"strconv"
+)
+
+func runTx(args []string) error {
+	return subcommands("tx", map[string]command{
+		"summary": {usage: "list the transaction history of a wallet", run: runTxSummary},
+		"details": {usage: "list the transactions of a wallet with fees and balances", run: runTxDetails},
+		"remark":  {usage: "set the remark of a transaction", run: runTxRemark},
+	}, args)
+}
+
+func runTxSummary(args []string) error {
+	fs, api := newAPIFlagSet("tx summary")
+	page := addPageFlags(fs)
+	var txTypes, addresses listFlag
+	var start, end timeFlag
+	walletCode := fs.String("wallet", "", "wallet code (required)")
+	coin := fs.String("coin", "", "only transactions of this coin")
+	fs.Var(&txTypes, "type", "only transactions of this type, e.g. DEPOSIT, repeatable")
+	fs.Var(&addresses, "address", "only transactions of this address, repeatable")
+	fs.Var(&start, "start", "only transactions at or after this time")
+	fs.Var(&end, "end", "only transactions before this time")
+	asc := fs.Bool("asc", false, "oldest first")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "wallet"); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	order := constants.OrderTypeNotUsed
+	if *asc {
+		order = constants.OrderTypeAsc
+	}
+	coinName, types := constants.CactusToken(*coin), typed[constants.TxType](txTypes)
+	startMs, endMs := unixMilli(start), unixMilli(end)
+	summaries, err := fetchList(page, func(offset int, limit int) (*cactus.GetWalletTransactionSummaryResp, error) {
+		return client.GetWalletTransactionHistoryWithContext(ctx, bId, *walletCode, coinName, types, addresses, offset, limit, order, startMs, endMs)
+	}, func(pageSize int) iter.Seq2[cactus.WalletTxSummary, error] {
+		return client.IterWalletTransactionHistory(ctx, bId, *walletCode, coinName, types, addresses, order, startMs, endMs, pageSize)
+	})
+	if err != nil {
+		return err
+	}
+	t := newTable("TIME", "TX_ID", "TYPE", "COIN", "AMOUNT", "BALANCE", "ORDER_NO", "REMARK")
+	for _, s := range summaries {
+		t.add(formatTime(s.TxTimeStamp), s.TxId, s.TxType, s.CoinName, s.Amount.String(), s.WalletBalance.String(), s.OrderNo, s.RemarkDetail)
+	}
+	return api.print(summaries, t)
+}
+
+// unixMilli is the millisecond timestamp the positional list arguments take, 0 for an unset flag
+func unixMilli(t timeFlag) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+func runTxDetails(args []string) error {
+	fs, api := newAPIFlagSet("tx details")
+	page := addPageFlags(fs)
+	var txTypes, addresses listFlag
+	var start, end timeFlag
+	walletCode := fs.String("wallet", "", "wallet code (required)")
+	coin := fs.String("coin", "", "only transactions of this coin")
+	fs.Var(&txTypes, "type", "only transactions of this type, e.g. DEPOSIT, repeatable")
+	fs.Var(&addresses, "address", "only transactions of this address, repeatable")
+	fs.Var(&start, "start", "only transactions at or after this time")
+	fs.Var(&end, "end", "only transactions before this time")
+	id := fs.String("id", "", "only the transaction with this id")
+	txId := fs.String("tx-id", "", "only the transaction with this hash")
+	orderNo := fs.String("order", "", "only transactions of this order")
+	asc := fs.Bool("asc", false, "oldest first")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "wallet"); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	filter := cactus.TxDetailFilter{
+		CoinName:        constants.CactusToken(*coin),
+		TxTypes:         typed[constants.TxType](txTypes),
+		Addresses:       addresses,
+		Id:              *id,
+		TxId:            *txId,
+		OrderNo:         *orderNo,
+		CreateTimeOrder: sortFlag(*asc),
+		Start:           start.Time,
+		End:             end.Time,
+	}
+	details, err := fetchList(page, func(offset int, limit int) (*cactus.GetTransactionDetailsResp, error) {
+		return client.ListTransactionDetailsWithContext(ctx, bId, *walletCode, filter, offset, limit)
+	}, func(pageSize int) iter.Seq2[cactus.WalletTxDetail, error] {
+		return client.IterTransactionDetails(ctx, bId, *walletCode, filter, pageSize)
+	})
+	if err != nil {
+		return err
+	}
+	t := newTable("ID", "TX_ID", "TYPE", "COIN", "DEPOSIT", "WITHDRAW", "FEE", "BALANCE", "ORDER_NO")
+	for _, d := range details {
+		orderNo := ""
+		if d.OrderNo != nil {
+			orderNo = fmt.Sprint(d.OrderNo)
+		}
+		t.add(strconv.Itoa(d.Id), d.TxId, string(d.TxType), d.CoinName, d.DepositAmount.String(),
+			d.WithdrawAmount.String(), d.TxFee.String(), d.WalletBalance.String(), orderNo)
+	}
+	return api.print(details, t)
+}
+
+func runTxRemark(args []string) error {
+	fs, api := newAPIFlagSet("tx remark")
+	walletCode := fs.String("wallet", "", "wallet code (required)")
+	id := fs.String("id", "", "transaction id, as listed by tx details (required)")
+	remark := fs.String("remark", "", "new remark, empty clears it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "wallet", "id"); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	if _, err = client.EditTransactionRemarkWithContext(ctx, bId, *walletCode, *id, *remark); err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "updated the remark of transaction %s\n", *id)
+	return nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"iter"
+	"strconv"
+)
+
+func runWallets(args []string) error {
+	return subcommands("wallets", map[string]command{
+		"list":   {usage: "list the wallets", run: runWalletsList},
+		"show":   {usage: "show a wallet", run: runWalletsShow},
+		"create": {usage: "create defi wallets", run: runWalletsCreate},
+	}, args)
+}
+
+func walletTable(wallets ...cactus.Wallet) *table {
+	t := newTable("WALLET_CODE", "NAME", "COIN", "TYPE", "STORAGE", "AVAILABLE", "FREEZE", "TOTAL", "USD")
+	for _, w := range wallets {
+		t.add(w.WalletCode, w.WalletName, w.CoinName, string(w.WalletType), string(w.StorageType),
+			w.AvailableAmount.String(), w.FreezeAmount.String(), w.TotalAmount.String(), formatFloat(w.UsdTotalMarket))
+	}
+	return t
+}
+
+func runWalletsList(args []string) error {
+	fs, api := newAPIFlagSet("wallets list")
+	page := addPageFlags(fs)
+	var coins listFlag
+	fs.Var(&coins, "coin", "only wallets holding this coin, repeatable")
+	storage := fs.String("storage", "", "HOT or COLD")
+	walletType := fs.String("type", "", "wallet type, e.g. "+string(constants.WalletTypeMixed))
+	chain := fs.String("chain", "", "only wallets on this chain")
+	keyword := fs.String("keyword", "", "search wallet names and codes")
+	hideEmpty := fs.Bool("hide-empty", false, "hide wallets without coins")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	filter := cactus.WalletFilter{
+		BId:              api.bId,
+		Type:             constants.WalletFilterType(*storage),
+		HideNoCoinWallet: *hideEmpty,
+		CoinNames:        typed[constants.CactusToken](coins),
+		WalletType:       constants.WalletType(*walletType),
+		Keyword:          *keyword,
+		Chain:            constants.ChainName(*chain),
+	}
+	wallets, err := fetchList(page, func(offset int, limit int) (*cactus.GetWalletListResp, error) {
+		return client.ListWalletsWithContext(ctx, filter, offset, limit)
+	}, func(pageSize int) iter.Seq2[cactus.Wallet, error] {
+		return client.IterWalletList(ctx, filter, pageSize)
+	})
+	if err != nil {
+		return err
+	}
+	return api.print(wallets, walletTable(wallets...))
+}
+
+func runWalletsShow(args []string) error {
+	fs, api := newAPIFlagSet("wallets show")
+	walletCode := fs.String("wallet", "", "wallet code (required)")
+	coin := fs.String("coin", "", "show the balance of this coin instead of the main coin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(fs, "wallet"); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.GetSingleWalletInfoWithContext(ctx, bId, *walletCode, constants.CactusToken(*coin))
+	if err != nil {
+		return err
+	}
+	return api.print(resp.Data, walletTable(resp.Data))
+}
+
+func runWalletsCreate(args []string) error {
+	fs, api := newAPIFlagSet("wallets create")
+	number := fs.Int("n", 1, "number of wallets to create")
+	walletType := fs.String("type", "DEFI", "wallet type, cactus only creates DEFI wallets")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	bId, err := api.business()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.CreateWalletWithContext(ctx, bId, *walletType, *number)
+	if err != nil {
+		return err
+	}
+	t := newTable("WALLET_CODE")
+	for _, code := range resp.Data {
+		t.add(code)
+	}
+	return api.print(resp.Data, t)
+}
+
+func runCoins(args []string) error {
+	fs, api := newAPIFlagSet("coins")
+	symbol := fs.String("symbol", "", "only the coin with this cactus symbol, e.g. USDT")
+	token := fs.String("token", "", "only coins with this on chain symbol")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.GetCoinInfoWithContext(ctx, *symbol, *token)
+	if err != nil {
+		return err
+	}
+	t := newTable("SYMBOL", "TOKEN", "CHAIN", "DECIMALS", "CONFIRMATIONS", "CONTRACT")
+	for _, coin := range resp.Data {
+		chain := coin.CactusChain
+		if chain == "" {
+			chain = coin.Chain
+		}
+		t.add(coin.CactusSymbol, coin.Symbol, chain, coin.Decimals, coin.ConfirmBlockNumber, coin.ContractAddress)
+	}
+	return api.print(resp.Data, t)
+}
+
+func runChains(args []string) error {
+	fs, api := newAPIFlagSet("chains")
+	chain := fs.String("chain", "", "only this chain")
+	fullName := fs.String("full-name", "", "only the chain with this full name")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	client, err := api.client()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+	resp, err := client.GetChainInfoWithContext(ctx, *chain, *fullName)
+	if err != nil {
+		return err
+	}
+	t := newTable("CHAIN", "FULL_NAME", "MAIN_COIN", "EVM", "EIP1559", "CONFIRMATIONS")
+	for _, info := range resp.Data {
+		t.add(info.Chain, info.FullName, info.MainCoin, strconv.FormatBool(info.EvmChain),
+			strconv.FormatBool(info.SupportEip1559), strconv.Itoa(info.ConfirmBlockNumber))
+	}
+	return api.print(resp.Data, t)
+}
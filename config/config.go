@@ -0,0 +1,577 @@
+// Package config loads named cactus client profiles, one per environment, tenant or business line
+//
+// A profile file is json, or yaml when its name ends in .yaml or .yml:
+//
+//	default: staging
+//	profiles:
+//	  staging:
+//	    environment: dev
+//	    api_key_id: 3f1b...
+//	    key:
+//	      file: keys/staging.pem
+//	      environment: dev
+//	    b_id: "1234"
+//	    timeout: 30s
+//	  production:
+//	    environment: production
+//	    api_key_id: 9c2e...
+//	    key:
+//	      remote_signer: /run/cactus-signer.sock
+//	      environment: production
+//	    b_id: "5678"
+//	    proxy: http://egress.internal:3128
+//
+// Secrets may be left out of the file and supplied by the CACTUS_* environment variables, which
+// override the selected profile. Each profile is bound to an environment, and outside local profiles
+// so is its key: a profile whose base uri is not a host of its environment, whose key is labeled with
+// another environment, or whose credentials also appear in a profile of another environment, is
+// refused. That keeps a production key from being sent to the dev host. A key replaced through
+// CACTUS_PRIVATE_KEY_FILE or CACTUS_REMOTE_SIGNER loses the label of the file and needs
+// CACTUS_KEY_ENVIRONMENT.
+//
+// Only the yaml needed for profiles is understood: nested mappings of scalars, comments and quoting.
+package config
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/keys"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/utils"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Environment is the cactus deployment a profile talks to
+type Environment string
+
+const (
+	Production Environment = "production"
+	Dev        Environment = "dev"
+	// Local is a fake api on the loopback interface, e.g. a cactustest server
+	Local Environment = "local"
+)
+
+// Base uris of the cactus environments
+const (
+	ProductionBaseURI = "https://api.mycactus.com"
+	DevBaseURI        = "https://api.mycactus.dev"
+)
+
+// environmentHosts are the hosts each environment may be reached at, Local takes any loopback host
+var environmentHosts = map[Environment][]string{
+	Production: {"api.mycactus.com"},
+	Dev:        {"api.mycactus.dev"},
+}
+
+// Environment variables read by LoadProfile and FromEnv, next to the CACTUS_* variables of the cactus package
+const (
+	EnvConfig         = "CACTUS_CONFIG"
+	EnvProfile        = "CACTUS_PROFILE"
+	EnvEnvironment    = "CACTUS_ENVIRONMENT"
+	EnvBId            = "CACTUS_B_ID"
+	EnvTimeout        = "CACTUS_TIMEOUT"
+	EnvProxy          = "CACTUS_PROXY"
+	EnvRemoteSigner   = "CACTUS_REMOTE_SIGNER"
+	EnvKeyEnvironment = "CACTUS_KEY_ENVIRONMENT"
+)
+
+var (
+	ErrUnknownProfile      = errors.New("config: unknown profile")
+	ErrEnvironmentMismatch = errors.New("config: base uri is not a host of the environment")
+	ErrSharedCredentials   = errors.New("config: credentials are shared across environments")
+	ErrKeyEnvironment      = errors.New("config: key belongs to another environment")
+)
+
+// File is a profile file
+type File struct {
+	// Default is the profile used when none is named, a file with one profile defaults to it
+	Default  string              `json:"default,omitempty"`
+	Profiles map[string]*Profile `json:"profiles"`
+}
+
+// Profile is everything needed to create a client for one tenant
+type Profile struct {
+	// Name is the key of the profile in its file
+	Name        string      `json:"-"`
+	Environment Environment `json:"environment"`
+	// BaseURI defaults to the base uri of Environment
+	BaseURI  string    `json:"base_uri,omitempty"`
+	APIKey   string    `json:"api_key,omitempty"`
+	APIKeyID string    `json:"api_key_id,omitempty"`
+	Key      KeySource `json:"key"`
+	// BId is the default business id of the calls made with this profile
+	BId string `json:"b_id,omitempty"`
+	// Timeout bounds a whole call, ConnectTimeout the tcp and tls handshakes, 0 for no limit
+	Timeout        Duration `json:"timeout,omitempty"`
+	ConnectTimeout Duration `json:"connect_timeout,omitempty"`
+	// Proxy is the url of an http or socks5 proxy, empty uses the HTTPS_PROXY environment variables
+	Proxy string `json:"proxy,omitempty"`
+
+	dir string // directory of the profile file, relative key files are resolved against it
+}
+
+// KeySource is where the signing key of a profile comes from, exactly one field but PassphraseEnv is set
+type KeySource struct {
+	// File is a PEM private key, relative to the profile file
+	File string `json:"file,omitempty"`
+	// Env names an environment variable holding the PEM private key
+	Env string `json:"env,omitempty"`
+	// RemoteSigner is the unix socket of a utils.RemoteSigner daemon, the key never enters the process
+	RemoteSigner string `json:"remote_signer,omitempty"`
+	// PassphraseEnv names the variable holding the passphrase of an encrypted key,
+	// defaults to CACTUS_PRIVATE_KEY_PASSPHRASE
+	PassphraseEnv string `json:"passphrase_env,omitempty"`
+	// Environment is the environment the key was issued for, required unless the profile is local
+	// A profile of another environment refuses the key.
+	Environment Environment `json:"environment,omitempty"`
+}
+
+// Duration is a time.Duration written like "30s" or "1m30s"
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("config: duration must be a string like \"30s\": %s", data)
+	}
+	if s == "" {
+		*d = 0
+		return nil
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Load reads a profile file and checks its profiles are consistent
+// Missing credentials are not an error yet, they may come from the environment, see File.Profile.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		m, err := parseYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if data, err = json.Marshal(m); err != nil {
+			return nil, err
+		}
+	}
+	var f File
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	dir := filepath.Dir(path)
+	var errs []error
+	for name, p := range f.Profiles {
+		if p == nil {
+			p = &Profile{}
+			f.Profiles[name] = p
+		}
+		p.Name, p.dir = name, dir
+		if err = p.check(false); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err = errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if f.Default != "" && f.Profiles[f.Default] == nil {
+		return nil, fmt.Errorf("%s: default %w %q", path, ErrUnknownProfile, f.Default)
+	}
+	if err = checkShared(f.list()); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// list returns the profiles sorted by name, so errors come out in a stable order
+func (f *File) list() []*Profile {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	list := make([]*Profile, len(names))
+	for i, name := range names {
+		list[i] = f.Profiles[name]
+	}
+	return list
+}
+
+// Profile returns a copy of the named profile with the CACTUS_* environment overrides applied
+// An empty name uses $CACTUS_PROFILE, then Default, then the only profile of the file.
+// The result is complete and valid, and shares no credentials with profiles of other environments.
+func (f *File) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = os.Getenv(EnvProfile)
+	}
+	if name == "" {
+		name = f.Default
+	}
+	if name == "" && len(f.Profiles) == 1 {
+		for only := range f.Profiles {
+			name = only
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("config: no profile selected, pass one or set $%s or default", EnvProfile)
+	}
+	base, ok := f.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownProfile, name)
+	}
+	p := *base
+	if err := p.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	others := f.list()
+	others[slices.Index(others, base)] = &p
+	if err := checkShared(others); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// LoadProfile loads the named profile of the file at path, see File.Profile
+// An empty path uses $CACTUS_CONFIG, without one the profile is built by FromEnv.
+func LoadProfile(path string, name string) (*Profile, error) {
+	if path == "" {
+		path = os.Getenv(EnvConfig)
+	}
+	if path == "" {
+		if name != "" {
+			return nil, fmt.Errorf("config: profile %q selected without a profile file", name)
+		}
+		return FromEnv()
+	}
+	f, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return f.Profile(name)
+}
+
+// FromEnv builds a profile from the CACTUS_* environment variables alone
+// The environment is taken from CACTUS_ENVIRONMENT, or else from the host of CACTUS_BASE_URI.
+// Without other profiles its credentials are not checked for sharing, but the key must still be
+// labeled with CACTUS_KEY_ENVIRONMENT outside a local environment.
+func FromEnv() (*Profile, error) {
+	p := &Profile{Name: "env"}
+	if err := p.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// applyEnv overrides the profile with the CACTUS_* variables that are set
+func (p *Profile) applyEnv() error {
+	set := func(field *string, key string) {
+		if v := os.Getenv(key); v != "" {
+			*field = v
+		}
+	}
+	if v := os.Getenv(EnvEnvironment); v != "" {
+		p.Environment = Environment(v)
+	}
+	set(&p.BaseURI, cactus.EnvBaseUri)
+	set(&p.APIKey, cactus.EnvApiKey)
+	set(&p.APIKeyID, cactus.EnvApiKeyID)
+	set(&p.BId, EnvBId)
+	set(&p.Proxy, EnvProxy)
+	// A replaced key does not keep the environment label of the file
+	if v := os.Getenv(cactus.EnvPrivateKeyFile); v != "" {
+		p.Key = KeySource{File: v, PassphraseEnv: p.Key.PassphraseEnv}
+	}
+	if v := os.Getenv(EnvRemoteSigner); v != "" {
+		p.Key = KeySource{RemoteSigner: v}
+	}
+	if v := os.Getenv(EnvKeyEnvironment); v != "" {
+		p.Key.Environment = Environment(v)
+	}
+	if v := os.Getenv(EnvTimeout); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("config: $%s: %w", EnvTimeout, err)
+		}
+		p.Timeout = Duration(timeout)
+	}
+	return nil
+}
+
+// Validate checks the profile is complete and its base uri belongs to its environment
+func (p *Profile) Validate() error {
+	return p.check(true)
+}
+
+// check validates the profile, complete also requires the credentials
+// An empty Environment is taken from the host of BaseURI, an empty BaseURI from Environment.
+func (p *Profile) check(complete bool) error {
+	var errs []error
+	if p.Environment == "" && p.BaseURI != "" {
+		p.Environment = environmentOf(p.BaseURI)
+	}
+	switch p.Environment {
+	case Production:
+		if p.BaseURI == "" {
+			p.BaseURI = ProductionBaseURI
+		}
+	case Dev:
+		if p.BaseURI == "" {
+			p.BaseURI = DevBaseURI
+		}
+	case Local:
+		if p.BaseURI == "" {
+			errs = append(errs, errors.New("a local profile needs a base uri"))
+		}
+	case "":
+		errs = append(errs, errors.New("environment is not set"))
+	default:
+		errs = append(errs, fmt.Errorf("unknown environment %q, want production, dev or local", p.Environment))
+	}
+	if p.BaseURI != "" && p.Environment != "" {
+		if err := checkHost(p.Environment, p.BaseURI); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	sources := 0
+	for _, source := range []string{p.Key.File, p.Key.Env, p.Key.RemoteSigner} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		errs = append(errs, errors.New("key sets more than one of file, env and remote_signer"))
+	}
+	switch {
+	case p.Key.Environment == "" || p.Environment == "":
+	case !slices.Contains([]Environment{Production, Dev, Local}, p.Key.Environment):
+		errs = append(errs, fmt.Errorf("unknown key environment %q, want production, dev or local", p.Key.Environment))
+	case p.Key.Environment != p.Environment:
+		errs = append(errs, fmt.Errorf("%w: the %s key is used by a %s profile", ErrKeyEnvironment, p.Key.Environment, p.Environment))
+	}
+	if complete {
+		if p.APIKey == "" {
+			errs = append(errs, errors.New("api key is not set"))
+		}
+		if p.APIKeyID == "" {
+			errs = append(errs, errors.New("api key id is not set"))
+		}
+		if sources == 0 {
+			errs = append(errs, errors.New("key is not set"))
+		} else if p.Key.Environment == "" && p.Environment != Local {
+			errs = append(errs, fmt.Errorf("key environment is not set, label the key or set $%s", EnvKeyEnvironment))
+		}
+	}
+	if p.Timeout < 0 || p.ConnectTimeout < 0 {
+		errs = append(errs, errors.New("timeouts can not be negative"))
+	}
+	if p.Proxy != "" {
+		if u, err := url.Parse(p.Proxy); err != nil || u.Host == "" || !slices.Contains([]string{"http", "https", "socks5"}, u.Scheme) {
+			errs = append(errs, fmt.Errorf("invalid proxy %q, want an http, https or socks5 url", p.Proxy))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("profile %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+// environmentOf guesses the environment of a base uri, "" if the host is not known
+func environmentOf(baseURI string) Environment {
+	u, err := url.Parse(baseURI)
+	if err != nil {
+		return ""
+	}
+	if isLoopback(u.Hostname()) {
+		return Local
+	}
+	for env, hosts := range environmentHosts {
+		if slices.Contains(hosts, u.Hostname()) {
+			return env
+		}
+	}
+	return ""
+}
+
+func checkHost(env Environment, baseURI string) error {
+	u, err := url.Parse(baseURI)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid base uri %q", baseURI)
+	}
+	if env == Local {
+		if !isLoopback(u.Hostname()) {
+			return fmt.Errorf("%w: %s is not a loopback host", ErrEnvironmentMismatch, u.Host)
+		}
+		return nil
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("base uri %q of a %s profile must use https", baseURI, env)
+	}
+	if !slices.Contains(environmentHosts[env], u.Hostname()) {
+		return fmt.Errorf("%w: %s is not a %s host", ErrEnvironmentMismatch, u.Host, env)
+	}
+	return nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// checkShared refuses an api key, key id or key source used by profiles of different environments
+func checkShared(profiles []*Profile) error {
+	type owner struct {
+		profile string
+		env     Environment
+	}
+	owners := map[string]owner{}
+	var errs []error
+	for _, p := range profiles {
+		credentials := map[string]string{
+			"api key":       p.APIKey,
+			"api key id":    p.APIKeyID,
+			"key file":      p.keyFile(),
+			"key env":       p.Key.Env,
+			"remote signer": p.Key.RemoteSigner,
+		}
+		for kind, value := range credentials {
+			if value == "" {
+				continue
+			}
+			key := kind + "\x00" + value
+			o, ok := owners[key]
+			if !ok {
+				owners[key] = owner{profile: p.Name, env: p.Environment}
+				continue
+			}
+			if o.env != p.Environment {
+				errs = append(errs, fmt.Errorf("%w: profile %s (%s) uses the %s of profile %s (%s)",
+					ErrSharedCredentials, p.Name, p.Environment, kind, o.profile, o.env))
+			}
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return errors.Join(errs...)
+}
+
+// keyFile is the key file path resolved against the profile file
+func (p *Profile) keyFile() string {
+	if p.Key.File == "" || filepath.IsAbs(p.Key.File) || p.dir == "" {
+		return p.Key.File
+	}
+	return filepath.Join(p.dir, p.Key.File)
+}
+
+// HTTPClient returns an http client applying the timeouts and proxy of the profile
+func (p *Profile) HTTPClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if p.Proxy != "" {
+		proxy, err := url.Parse(p.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("config: invalid proxy: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if p.ConnectTimeout > 0 {
+		dialer := &net.Dialer{Timeout: time.Duration(p.ConnectTimeout), KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+		transport.TLSHandshakeTimeout = time.Duration(p.ConnectTimeout)
+	}
+	return &http.Client{Transport: transport, Timeout: time.Duration(p.Timeout)}, nil
+}
+
+// NewClient validates the profile and creates a client signing with its key source
+func (p *Profile) NewClient() (*cactus.Cactus, error) {
+	creds, err := p.Credentials()
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := p.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	if creds.Signer != nil {
+		return cactus.NewCactusWithSigner(creds.BaseUri, creds.XApiKey, creds.ApiKeyID, creds.Signer, httpClient, 0), nil
+	}
+	return cactus.NewCactus(creds.BaseUri, creds.XApiKey, creds.ApiKeyID, creds.PrivateKey, httpClient, 0), nil
+}
+
+// Credentials validates the profile and loads its key, e.g. to rotate a pool tenant
+func (p *Profile) Credentials() (cactus.Credentials, error) {
+	if err := p.Validate(); err != nil {
+		return cactus.Credentials{}, err
+	}
+	creds := cactus.Credentials{BaseUri: p.BaseURI, XApiKey: p.APIKey, ApiKeyID: p.APIKeyID}
+	if p.Key.RemoteSigner != "" {
+		creds.Signer = utils.NewRemoteSigner(p.Key.RemoteSigner, p.APIKeyID)
+		return creds, nil
+	}
+	key, err := p.privateKey()
+	if err != nil {
+		return cactus.Credentials{}, fmt.Errorf("profile %s: %w", p.Name, err)
+	}
+	creds.PrivateKey = key
+	return creds, nil
+}
+
+// Tenant returns the profile as a pool tenant named after it and serving its b_id
+// The timeouts and proxy of the profile do not apply, a pool shares one http client.
+func (p *Profile) Tenant() (cactus.Tenant, error) {
+	creds, err := p.Credentials()
+	if err != nil {
+		return cactus.Tenant{}, err
+	}
+	t := cactus.Tenant{Name: p.Name, Credentials: creds}
+	if p.BId != "" {
+		t.BIds = []string{p.BId}
+	}
+	return t, nil
+}
+
+func (p *Profile) privateKey() (*ecdsa.PrivateKey, error) {
+	passphraseEnv := p.Key.PassphraseEnv
+	if passphraseEnv == "" {
+		passphraseEnv = cactus.EnvPrivateKeyPassphrase
+	}
+	passphrase := []byte(os.Getenv(passphraseEnv))
+	if p.Key.Env != "" {
+		pemData := os.Getenv(p.Key.Env)
+		if pemData == "" {
+			return nil, fmt.Errorf("$%s holding the private key is not set", p.Key.Env)
+		}
+		return keys.ParsePrivateKeyPEM([]byte(pemData), passphrase)
+	}
+	return keys.LoadPrivateKeyFile(p.keyFile(), passphrase)
+}
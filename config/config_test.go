@@ -0,0 +1,247 @@
+package config
+
+import (
+	"errors"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactustest"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/keys"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv unsets every variable a profile can be overridden by
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{EnvConfig, EnvProfile, EnvEnvironment, EnvBId, EnvTimeout, EnvProxy, EnvRemoteSigner,
+		EnvKeyEnvironment, cactus.EnvBaseUri, cactus.EnvApiKey, cactus.EnvApiKeyID, cactus.EnvPrivateKeyFile, cactus.EnvPrivateKeyPassphrase} {
+		t.Setenv(key, "")
+	}
+}
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const profilesYAML = `# staging and production tenants
+default: staging
+profiles:
+  staging:
+    environment: dev
+    api_key: staging-key
+    api_key_id: staging-key-id
+    key:
+      file: keys/staging.pem
+      environment: dev
+    b_id: "1234"
+    timeout: 30s
+  production:
+    environment: production
+    api_key_id: 'production-key-id'
+    key:
+      remote_signer: /run/cactus-signer.sock
+      environment: production
+    b_id: "5678"   # trading desk
+    proxy: http://egress.internal:3128
+`
+
+const profilesJSON = `{
+  "default": "staging",
+  "profiles": {
+    "staging": {"environment": "dev", "api_key": "staging-key", "api_key_id": "staging-key-id",
+      "key": {"file": "keys/staging.pem", "environment": "dev"}, "b_id": "1234", "timeout": "30s"},
+    "production": {"environment": "production", "api_key_id": "production-key-id",
+      "key": {"remote_signer": "/run/cactus-signer.sock", "environment": "production"}, "b_id": "5678", "proxy": "http://egress.internal:3128"}
+  }
+}`
+
+func TestLoadProfiles(t *testing.T) {
+	clearEnv(t)
+	dir := t.TempDir()
+	for _, path := range []string{writeFile(t, dir, "profiles.yaml", profilesYAML), writeFile(t, dir, "profiles.json", profilesJSON)} {
+		f, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s) error = %v", path, err)
+		}
+		staging, err := f.Profile("")
+		if err != nil {
+			t.Fatalf("Profile() error = %v", err)
+		}
+		if staging.Name != "staging" || staging.BaseURI != DevBaseURI || staging.BId != "1234" || time.Duration(staging.Timeout) != 30*time.Second {
+			t.Errorf("%s: default profile = %+v", path, staging)
+		}
+		if got := staging.keyFile(); got != filepath.Join(dir, "keys", "staging.pem") {
+			t.Errorf("%s: key file = %q, want it relative to the profile file", path, got)
+		}
+		// The production api key only comes from the environment
+		if _, err = f.Profile("production"); err == nil || !strings.Contains(err.Error(), "api key is not set") {
+			t.Errorf("%s: Profile(production) error = %v, want a missing api key", path, err)
+		}
+		t.Setenv(cactus.EnvApiKey, "production-key")
+		t.Setenv(EnvBId, "9999")
+		production, err := f.Profile("production")
+		if err != nil {
+			t.Fatalf("%s: Profile(production) error = %v", path, err)
+		}
+		if production.BaseURI != ProductionBaseURI || production.APIKey != "production-key" || production.BId != "9999" {
+			t.Errorf("%s: production profile = %+v, want the environment overrides", path, production)
+		}
+		if f.Profiles["production"].APIKey != "" {
+			t.Errorf("%s: overrides leaked into the loaded file", path)
+		}
+		t.Setenv(cactus.EnvApiKey, "")
+		t.Setenv(EnvBId, "")
+	}
+}
+
+func TestRefusesMixedEnvironments(t *testing.T) {
+	tests := []struct {
+		name string
+		// replace holds profiles replacing the valid production and staging profiles
+		replace map[string]string
+		env     map[string]string
+		want    error
+	}{
+		{
+			name: "production profile on the dev host",
+			replace: map[string]string{"production": `{"environment": "production", "base_uri": "https://api.mycactus.dev",
+				"api_key": "production-key", "api_key_id": "production-key-id", "key": {"env": "PRODUCTION_KEY", "environment": "production"}}`},
+			want: ErrEnvironmentMismatch,
+		},
+		{
+			name: "dev profile reusing the production key id",
+			replace: map[string]string{"staging": `{"environment": "dev",
+				"api_key": "staging-key", "api_key_id": "production-key-id", "key": {"env": "STAGING_KEY", "environment": "dev"}}`},
+			want: ErrSharedCredentials,
+		},
+		{
+			name: "production profile pointed at the dev host",
+			env:  map[string]string{EnvProfile: "production", cactus.EnvBaseUri: "https://api.mycactus.dev"},
+			want: ErrEnvironmentMismatch,
+		},
+		{
+			name: "dev profile given the production key id",
+			env:  map[string]string{EnvProfile: "staging", cactus.EnvApiKeyID: "production-key-id"},
+			want: ErrSharedCredentials,
+		},
+		{
+			name: "dev profile with a production key",
+			replace: map[string]string{"staging": `{"environment": "dev",
+				"api_key": "staging-key", "api_key_id": "staging-key-id", "key": {"file": "production.pem", "environment": "production"}}`},
+			want: ErrKeyEnvironment,
+		},
+		{
+			name: "dev profile given a production key",
+			env:  map[string]string{EnvProfile: "staging", cactus.EnvPrivateKeyFile: "production.pem", EnvKeyEnvironment: "production"},
+			want: ErrKeyEnvironment,
+		},
+		{
+			name: "local profile on a remote host",
+			env:  map[string]string{EnvProfile: "staging", EnvEnvironment: "local", cactus.EnvBaseUri: "http://10.0.0.1:8080"},
+			want: ErrEnvironmentMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			profiles := map[string]string{
+				"production": `{"environment": "production", "api_key": "production-key", "api_key_id": "production-key-id", "key": {"env": "PRODUCTION_KEY", "environment": "production"}}`,
+				"staging":    `{"environment": "dev", "api_key": "staging-key", "api_key_id": "staging-key-id", "key": {"env": "STAGING_KEY", "environment": "dev"}}`,
+			}
+			for name, profile := range tt.replace {
+				profiles[name] = profile
+			}
+			path := writeFile(t, t.TempDir(), "profiles.json",
+				`{"profiles": {"production": `+profiles["production"]+`, "staging": `+profiles["staging"]+`}}`)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if _, err := LoadProfile(path, ""); !errors.Is(err, tt.want) {
+				t.Errorf("LoadProfile() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClientFromProfile(t *testing.T) {
+	clearEnv(t)
+	srv := cactustest.NewServer()
+	defer srv.Close()
+	dir := t.TempDir()
+	if err := keys.WritePrivateKeyFile(filepath.Join(dir, "local.pem"), srv.PrivateKey, []byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	path := writeFile(t, dir, "profiles.yml", `profiles:
+  local:
+    base_uri: `+srv.URL+`
+    api_key: `+srv.APIKey+`
+    api_key_id: `+srv.KeyID+`
+    key:
+      file: local.pem
+      passphrase_env: LOCAL_PASSPHRASE
+    connect_timeout: 5s
+`)
+	t.Setenv("LOCAL_PASSPHRASE", "secret")
+	p, err := LoadProfile(path, "local")
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+	if p.Environment != Local {
+		t.Errorf("Environment = %q, want it taken from the loopback base uri", p.Environment)
+	}
+	client, err := p.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	client.Logger = cactus.NopLogger{}
+	if _, err = client.GetChainInfo("", ""); err != nil {
+		t.Errorf("GetChainInfo() error = %v", err)
+	}
+
+	tenant, err := p.Tenant()
+	if err != nil {
+		t.Fatalf("Tenant() error = %v", err)
+	}
+	pool := cactus.NewPool(nil, nil)
+	if err = pool.Add(tenant); err != nil {
+		t.Fatalf("Pool.Add() error = %v", err)
+	}
+	pooled, err := pool.Client("local")
+	if err != nil {
+		t.Fatalf("Pool.Client() error = %v", err)
+	}
+	if _, err = pooled.GetChainInfo("", ""); err != nil {
+		t.Errorf("pooled GetChainInfo() error = %v", err)
+	}
+}
+
+func TestKeyEnvironmentRequired(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, t.TempDir(), "profiles.json", `{"profiles": {"staging": {"environment": "dev",
+		"api_key": "staging-key", "api_key_id": "staging-key-id", "key": {"env": "STAGING_KEY", "environment": "dev"}}}}`)
+	// Replacing the key drops the label of the file
+	t.Setenv(cactus.EnvPrivateKeyFile, "other.pem")
+	if _, err := LoadProfile(path, ""); err == nil || !strings.Contains(err.Error(), "key environment is not set") {
+		t.Errorf("LoadProfile() with an unlabeled key error = %v", err)
+	}
+	t.Setenv(EnvKeyEnvironment, "dev")
+	if _, err := LoadProfile(path, ""); err != nil {
+		t.Errorf("LoadProfile() with a labeled key error = %v", err)
+	}
+
+	clearEnv(t)
+	t.Setenv(cactus.EnvBaseUri, DevBaseURI)
+	t.Setenv(cactus.EnvApiKey, "staging-key")
+	t.Setenv(cactus.EnvApiKeyID, "staging-key-id")
+	t.Setenv(cactus.EnvPrivateKeyFile, "staging.pem")
+	if _, err := FromEnv(); err == nil || !strings.Contains(err.Error(), "key environment is not set") {
+		t.Errorf("FromEnv() with an unlabeled key error = %v", err)
+	}
+}
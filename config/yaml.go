@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a non empty line of a yaml mapping, value is empty for a key opening a nested mapping
+type yamlLine struct {
+	num    int
+	indent int
+	key    string
+	value  any
+}
+
+// parseYAML parses the yaml subset profile files use into maps of strings
+// Supported are nested block mappings, plain, single and double quoted scalars, comments and a
+// leading document marker. Sequences, flow collections, anchors and block scalars are rejected.
+// Scalars stay strings, null and ~ become nil, the profile fields do the type conversion.
+func parseYAML(data []byte) (map[string]any, error) {
+	var lines []yamlLine
+	for i, text := range strings.Split(string(data), "\n") {
+		line, ok, err := parseYAMLLine(i+1, strings.TrimRight(text, "\r"))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return map[string]any{}, nil
+	}
+	m, rest, err := parseYAMLMapping(lines, lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("yaml line %d: unexpected indentation", rest[0].num)
+	}
+	return m, nil
+}
+
+func parseYAMLMapping(lines []yamlLine, indent int) (map[string]any, []yamlLine, error) {
+	m := map[string]any{}
+	for len(lines) > 0 {
+		line := lines[0]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, nil, fmt.Errorf("yaml line %d: unexpected indentation", line.num)
+		}
+		lines = lines[1:]
+		if _, ok := m[line.key]; ok {
+			return nil, nil, fmt.Errorf("yaml line %d: duplicate key %q", line.num, line.key)
+		}
+		if line.value != "" || len(lines) == 0 || lines[0].indent <= indent {
+			m[line.key] = line.value
+			continue
+		}
+		child, rest, err := parseYAMLMapping(lines, lines[0].indent)
+		if err != nil {
+			return nil, nil, err
+		}
+		m[line.key], lines = child, rest
+	}
+	return m, lines, nil
+}
+
+// parseYAMLLine splits a line into its key and scalar value, ok is false for blank and comment lines
+func parseYAMLLine(num int, text string) (line yamlLine, ok bool, err error) {
+	trimmed := strings.TrimLeft(text, " ")
+	if strings.TrimSpace(trimmed) == "" || trimmed[0] == '#' || num == 1 && trimmed == "---" {
+		return line, false, nil
+	}
+	if strings.HasPrefix(trimmed, "\t") {
+		return line, false, fmt.Errorf("yaml line %d: tabs can not indent yaml", num)
+	}
+	line.num, line.indent = num, len(text)-len(trimmed)
+	if strings.ContainsRune("-[{&*!|>%@`", rune(trimmed[0])) {
+		return line, false, fmt.Errorf("yaml line %d: only mappings of scalars are supported", num)
+	}
+	var rest string
+	if trimmed[0] == '"' || trimmed[0] == '\'' {
+		line.key, rest, err = yamlQuoted(num, trimmed)
+		if err != nil {
+			return line, false, err
+		}
+		if !strings.HasPrefix(rest, ":") {
+			return line, false, fmt.Errorf("yaml line %d: expected a colon after the key", num)
+		}
+		rest = rest[1:]
+	} else {
+		i := strings.Index(trimmed, ": ")
+		if i < 0 {
+			if !strings.HasSuffix(trimmed, ":") {
+				return line, false, fmt.Errorf("yaml line %d: expected key: value", num)
+			}
+			i = len(trimmed) - 1
+		}
+		line.key, rest = strings.TrimRight(trimmed[:i], " "), trimmed[i+1:]
+	}
+	if rest != "" && rest[0] != ' ' {
+		return line, false, fmt.Errorf("yaml line %d: expected a space after the colon", num)
+	}
+	line.value, err = yamlScalar(num, strings.TrimSpace(rest))
+	return line, true, err
+}
+
+// yamlScalar parses the value after a key, "" for none
+func yamlScalar(num int, s string) (any, error) {
+	if s == "" || s[0] == '#' {
+		return "", nil
+	}
+	if s[0] == '"' || s[0] == '\'' {
+		value, rest, err := yamlQuoted(num, s)
+		if err != nil {
+			return nil, err
+		}
+		if rest = strings.TrimSpace(rest); rest != "" && rest[0] != '#' {
+			return nil, fmt.Errorf("yaml line %d: unexpected %q after the quoted value", num, rest)
+		}
+		return value, nil
+	}
+	if strings.ContainsRune("[{&*!|>%@`", rune(s[0])) {
+		return nil, fmt.Errorf("yaml line %d: only scalar values are supported", num)
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = strings.TrimRight(s[:i], " ")
+	}
+	if s == "null" || s == "~" {
+		return nil, nil
+	}
+	return s, nil
+}
+
+// yamlQuoted parses the quoted string s starts with and returns the text after it
+func yamlQuoted(num int, s string) (value string, rest string, err error) {
+	if s[0] == '\'' {
+		var b strings.Builder
+		for i := 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				b.WriteByte(s[i])
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return b.String(), s[i+1:], nil
+		}
+		return "", "", fmt.Errorf("yaml line %d: unterminated quoted string", num)
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			value, err = strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", "", fmt.Errorf("yaml line %d: %w", num, err)
+			}
+			return value, s[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("yaml line %d: unterminated quoted string", num)
+}
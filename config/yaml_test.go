@@ -0,0 +1,63 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	got, err := parseYAML([]byte(`---
+# comment
+a: plain value # trailing comment
+b: "double \"quoted\" # not a comment"
+c: 'it''s'
+"quoted key": x
+empty:
+none: ~
+nested:
+    deeper:
+      url: http://host:8080/path
+    sibling: 1
+last: z
+`))
+	if err != nil {
+		t.Fatalf("parseYAML() error = %v", err)
+	}
+	want := map[string]any{
+		"a":          "plain value",
+		"b":          `double "quoted" # not a comment`,
+		"c":          "it's",
+		"quoted key": "x",
+		"empty":      "",
+		"none":       nil,
+		"nested": map[string]any{
+			"deeper":  map[string]any{"url": "http://host:8080/path"},
+			"sibling": "1",
+		},
+		"last": "z",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYAML() = %#v, want %#v", got, want)
+	}
+}
+
+func TestParseYAMLRejectsUnsupported(t *testing.T) {
+	tests := map[string]string{
+		"sequence":       "list:\n  - a\n",
+		"flow mapping":   "key: {a: b}\n",
+		"anchor":         "key: &anchor value\n",
+		"block scalar":   "key: |\n  text\n",
+		"tab indent":     "a:\n\tb: c\n",
+		"duplicate key":  "a: 1\na: 2\n",
+		"bad indent":     "a:\n    b: 1\n  c: 2\n",
+		"missing colon":  "just text\n",
+		"unterminated":   "a: \"open\n",
+		"no colon space": "a:b\n",
+	}
+	for name, input := range tests {
+		if _, err := parseYAML([]byte(input)); err == nil || !strings.Contains(err.Error(), "yaml line") {
+			t.Errorf("%s: parseYAML(%q) error = %v, want a line error", name, input, err)
+		}
+	}
+}
@@ -1,40 +1,42 @@
+// Code generated by genconstants; DO NOT EDIT.
+
 package constants
 
 type ChainName string
 
 const (
-	ChainNameETH      ChainName = "ETH"
-	ChainNameSOLANA   ChainName = "SOLANA"
 	ChainNameARB      ChainName = "ARB"
 	ChainNameAVAX     ChainName = "AVAX"
+	ChainNameBCH      ChainName = "BCH"
 	ChainNameBSC      ChainName = "BSC"
 	ChainNameBTC      ChainName = "BTC"
-	ChainNameBCH      ChainName = "BCH"
-	ChainNameSMARTBCH ChainName = "SMARTBCH"
-	ChainNameKLAY     ChainName = "KLAY"
-	ChainNameBTMC     ChainName = "BTMC"
 	ChainNameBTM      ChainName = "BTM"
+	ChainNameBTMC     ChainName = "BTMC"
 	ChainNameCARDANO  ChainName = "CARDANO"
-	ChainNameOPTIMISM ChainName = "OPTIMISM"
 	ChainNameDASH     ChainName = "DASH"
 	ChainNameDCR      ChainName = "DCR"
 	ChainNameDOGE     ChainName = "DOGE"
+	ChainNameDOT      ChainName = "DOT"
 	ChainNameECASH    ChainName = "ECASH"
 	ChainNameETC      ChainName = "ETC"
 	ChainNameETF      ChainName = "ETF"
+	ChainNameETH      ChainName = "ETH"
 	ChainNameETHW     ChainName = "ETHW"
-	ChainNameFTM      ChainName = "FTM"
 	ChainNameFIL      ChainName = "FIL"
+	ChainNameFTM      ChainName = "FTM"
 	ChainNameHECO     ChainName = "HECO"
+	ChainNameKLAY     ChainName = "KLAY"
 	ChainNameKSM      ChainName = "KSM"
 	ChainNameLTC      ChainName = "LTC"
-	ChainNameNEAR     ChainName = "NEAR"
-	ChainNameDOT      ChainName = "DOT"
 	ChainNameMATIC    ChainName = "MATIC"
+	ChainNameNEAR     ChainName = "NEAR"
+	ChainNameOPTIMISM ChainName = "OPTIMISM"
+	ChainNameSMARTBCH ChainName = "SMARTBCH"
+	ChainNameSOLANA   ChainName = "SOLANA"
 	ChainNameSTELLAR  ChainName = "STELLAR"
-	ChainNameTRON     ChainName = "TRON"
 	ChainNameTON      ChainName = "TON"
-	ChainNameZEC      ChainName = "ZEC"
+	ChainNameTRON     ChainName = "TRON"
 	ChainNameXRP      ChainName = "XRP"
+	ChainNameZEC      ChainName = "ZEC"
 	ChainNameZKSYNC   ChainName = "ZKSYNC"
 )
@@ -0,0 +1,6 @@
+package constants
+
+// tokens.go and chains.go are generated from snapshot.json, add -live to refresh the snapshot from the api
+// The committed snapshot only carries the symbols of the former hand kept list, so no metadata is
+// generated from it until it is refreshed with -live.
+//go:generate go run ../internal/genconstants -snapshot snapshot.json -out .
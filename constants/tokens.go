@@ -1,191 +1,194 @@
+// Code generated by genconstants; DO NOT EDIT.
+
 package constants
 
 type CactusToken string
 
-// From https://docs.google.com/spreadsheets/d/1oIG-mq-QSzeqXtMOykVtCVKI6g9_KIjLrCoizhQwNvQ/edit#gid=0
-
 const CactusTokenNotProvided CactusToken = ""
-const CactusTokenZrx CactusToken = "ZRX"
-const CactusTokenSafuSol CactusToken = "SAFU_SOL"
-const CactusTokenAave CactusToken = "AAVE"
-const CactusTokenChf CactusToken = "CHF"
-const CactusTokenAnkr CactusToken = "ANKR"
-const CactusTokenAidogeArbitrum CactusToken = "AIDOGE_ARBITRUM"
-const CactusTokenAvaxAvax CactusToken = "AVAX_AVAX"
-const CactusTokenAvdo CactusToken = "AVDO"
-const CactusTokenBadger CactusToken = "BADGER"
-const CactusTokenBcrvrenwbtc CactusToken = "BCRVRENWBTC"
-const CactusTokenBnt CactusToken = "BNT"
-const CactusTokenBond CactusToken = "BOND"
-const CactusTokenBat CactusToken = "BAT"
-const CactusTokenBethBsc CactusToken = "BETH_BSC"
-const CactusTokenQiAvax CactusToken = "QI_AVAX"
-const CactusTokenBnbBsc CactusToken = "BNB_BSC"
-const CactusTokenBusd CactusToken = "BUSD"
-const CactusTokenBbtc CactusToken = "BBTC"
-const CactusTokenCakeBsc CactusToken = "CAKE_BSC"
-const CactusTokenBtc CactusToken = "BTC"
-const CactusTokenBch CactusToken = "BCH"
-const CactusTokenBchSmartbch CactusToken = "BCH_SMARTBCH"
-const CactusTokenBit CactusToken = "BIT"
-const CactusTokenBoraKlay CactusToken = "BORA_KLAY"
-const CactusTokenBtmn CactusToken = "BTMN"
-const CactusTokenBtm CactusToken = "BTM"
-const CactusTokenAda CactusToken = "ADA"
-const CactusTokenCel CactusToken = "CEL"
-const CactusTokenChad CactusToken = "CHAD"
-const CactusTokenLink CactusToken = "LINK"
-const CactusTokenChz CactusToken = "CHZ"
-const CactusTokenComp CactusToken = "COMP"
-const CactusTokenCeth CactusToken = "CETH"
-const CactusTokenCusdc CactusToken = "CUSDC"
-const CactusTokenCusdt CactusToken = "CUSDT"
-const CactusTokenCwbtc CactusToken = "CWBTC"
-const CactusTokenCcbtc CactusToken = "CCBTC"
-const CactusTokenCrv CactusToken = "CRV"
-const CactusTokenCdaicusdc CactusToken = "CDAICUSDC"
-const CactusToken3crv CactusToken = "3CRV"
-const CactusTokenCrvplain3andsusd CactusToken = "CRVPLAIN3ANDSUSD"
-const CactusTokenStecrv CactusToken = "STECRV"
-const CactusTokenHcrv CactusToken = "HCRV"
-const CactusTokenCrvrenwbtc CactusToken = "CRVRENWBTC"
-const CactusTokenCrv3crypto CactusToken = "CRV3CRYPTO"
-const CactusTokenYdaiyusdcyusdtybusd CactusToken = "YDAIYUSDCYUSDTYBUSD"
-const CactusTokenYdaiyusdcyusdtytusd CactusToken = "YDAIYUSDCYUSDTYTUSD"
-const CactusTokenDai CactusToken = "DAI"
-const CactusTokenArbArbitrum CactusToken = "ARB_ARBITRUM"
-const CactusTokenDaiOptimism CactusToken = "DAI_OPTIMISM"
-const CactusTokenDash CactusToken = "DASH"
-const CactusTokenMana CactusToken = "MANA"
-const CactusTokenUsdd CactusToken = "USDD"
-const CactusTokenDcr CactusToken = "DCR"
-const CactusTokenDf CactusToken = "DF"
-const CactusTokenLeash CactusToken = "LEASH"
-const CactusTokenDoge CactusToken = "DOGE"
-const CactusTokenBcha CactusToken = "BCHA"
-const CactusTokenEnj CactusToken = "ENJ"
-const CactusTokenEth CactusToken = "ETH"
-const CactusTokenUsdcArbitrum CactusToken = "USDC_ARBITRUM"
-const CactusTokenEthBsc CactusToken = "ETH_BSC"
-const CactusTokenEthOptimism CactusToken = "ETH_OPTIMISM"
-const CactusTokenEtc CactusToken = "ETC"
-const CactusTokenEthEtf CactusToken = "ETH_ETF"
-const CactusTokenEthEthw CactusToken = "ETH_ETHW"
-const CactusTokenEvry CactusToken = "EVRY"
-const CactusTokenFtmFantom CactusToken = "FTM_FANTOM"
-const CactusTokenFbtcFantom CactusToken = "FBTC_FANTOM"
-const CactusTokenFethFantom CactusToken = "FETH_FANTOM"
-const CactusTokenFil CactusToken = "FIL"
-const CactusTokenFly CactusToken = "FLY"
-const CactusTokenFwb CactusToken = "FWB"
-const CactusTokenFtt CactusToken = "FTT"
-const CactusTokenGeneSol CactusToken = "GENE_SOL"
-const CactusTokenEben CactusToken = "EBEN"
-const CactusTokenHigh CactusToken = "HIGH"
-const CactusTokenHot CactusToken = "HOT"
-const CactusTokenHbtc CactusToken = "HBTC"
-const CactusTokenHtHeco CactusToken = "HT_HECO"
-const CactusTokenHt CactusToken = "HT"
-const CactusTokenHusd CactusToken = "HUSD"
-const CactusTokenJoeAvax CactusToken = "JOE_AVAX"
-const CactusTokenKlayKlay CactusToken = "KLAY_KLAY"
-const CactusTokenKsm CactusToken = "KSM"
-const CactusTokenLusd CactusToken = "LUSD"
-const CactusTokenLtc CactusToken = "LTC"
-const CactusTokenLon CactusToken = "LON"
-const CactusTokenDaiArbitrum CactusToken = "DAI_ARBITRUM"
-const CactusTokenMim CactusToken = "MIM"
-const CactusTokenMkr CactusToken = "MKR"
-const CactusTokenMapsSol CactusToken = "MAPS_SOL"
-const CactusTokenNear CactusToken = "NEAR"
-const CactusTokenNexo CactusToken = "NEXO"
-const CactusTokenNftx CactusToken = "NFTX"
-const CactusTokenOkb CactusToken = "OKB"
-const CactusTokenOmg CactusToken = "OMG"
-const CactusTokenOxySol CactusToken = "OXY_SOL"
-const CactusTokenUsdtBsc CactusToken = "USDT_BSC"
-const CactusTokenPngAvax CactusToken = "PNG_AVAX"
-const CactusTokenUsdp CactusToken = "USDP"
-const CactusTokenPepe CactusToken = "PEPE"
-const CactusTokenDot CactusToken = "DOT"
-const CactusTokenMatic CactusToken = "MATIC"
-const CactusTokenMaticPolygon CactusToken = "MATIC_POLYGON"
-const CactusTokenD2d CactusToken = "D2D"
-const CactusTokenGal CactusToken = "GAL"
-const CactusTokenEthArbitrum CactusToken = "ETH_ARBITRUM"
-const CactusTokenRev CactusToken = "REV"
-const CactusTokenSrmSol CactusToken = "SRM_SOL"
-const CactusTokenShib CactusToken = "SHIB"
-const CactusTokenSol CactusToken = "SOL"
-const CactusTokenSlndSol CactusToken = "SLND_SOL"
-const CactusTokenSpiritFantom CactusToken = "SPIRIT_FANTOM"
-const CactusTokenBooFantom CactusToken = "BOO_FANTOM"
-const CactusTokenXlm CactusToken = "XLM"
-const CactusTokenSteth CactusToken = "STETH"
-const CactusTokenSushi CactusToken = "SUSHI"
-const CactusTokenSweatNear CactusToken = "SWEAT_NEAR"
-const CactusTokenChsb CactusToken = "CHSB"
-const CactusTokenSusd CactusToken = "SUSD"
-const CactusTokenSnx CactusToken = "SNX"
-const CactusTokenTel CactusToken = "TEL"
-const CactusTokenMnt CactusToken = "MNT"
-const CactusTokenUsdterc CactusToken = "USDTERC"
-const CactusTokenMimArbitrum CactusToken = "MIM_ARBITRUM"
-const CactusTokenUsdtomni CactusToken = "USDTOMNI"
-const CactusTokenUsdtOptimism CactusToken = "USDT_OPTIMISM"
-const CactusTokenUsdttrc CactusToken = "USDTTRC"
-const CactusTokenUsdtNear CactusToken = "USDT_NEAR"
-const CactusTokenUsdtAvax CactusToken = "USDT_AVAX"
-const CactusTokenGrt CactusToken = "GRT"
-const CactusTokenImbtc CactusToken = "IMBTC"
-const CactusTokenTombFantom CactusToken = "TOMB_FANTOM"
-const CactusTokenTonTon CactusToken = "TON_TON"
-const CactusTokenTrx CactusToken = "TRX"
-const CactusTokenTusd CactusToken = "TUSD"
-const CactusTokenUma CactusToken = "UMA"
-const CactusTokenUni CactusToken = "UNI"
-const CactusTokenUniv2usdt2 CactusToken = "UNIV2USDT2"
-const CactusTokenUniv2usdc3 CactusToken = "UNIV2USDC3"
-const CactusTokenUniv2wbtc2 CactusToken = "UNIV2WBTC2"
-const CactusTokenLeo CactusToken = "LEO"
-const CactusTokenUsdc CactusToken = "USDC"
-const CactusTokenUsdcAvax CactusToken = "USDC_AVAX"
-const CactusTokenRdntArbitrum CactusToken = "RDNT_ARBITRUM"
-const CactusTokenUsdcFantom CactusToken = "USDC_FANTOM"
-const CactusTokenUsdcOptimism CactusToken = "USDC_OPTIMISM"
-const CactusTokenUsdcSol CactusToken = "USDC_SOL"
-const CactusTokenUsdcTrc CactusToken = "USDC_TRC"
-const CactusTokenUsdcNear CactusToken = "USDC_NEAR"
-const CactusTokenUsdcEAvax CactusToken = "USDC.E_AVAX"
-const CactusTokenUsdtSol CactusToken = "USDT_SOL"
-const CactusTokenVelo CactusToken = "VELO"
-const CactusTokenWojak CactusToken = "WOJAK"
-const CactusTokenWoo CactusToken = "WOO"
-const CactusTokenBtcSol CactusToken = "BTC_SOL"
-const CactusTokenUsdcBsc CactusToken = "USDC_BSC"
-const CactusTokenWbtc CactusToken = "WBTC"
-const CactusTokenUsdtArbitrum CactusToken = "USDT_ARBITRUM"
-const CactusTokenWbtcOptimism CactusToken = "WBTC_OPTIMISM"
-const CactusTokenWeth CactusToken = "WETH"
-const CactusTokenWethOptimism CactusToken = "WETH_OPTIMISM"
-const CactusTokenSoethSol CactusToken = "SOETH_SOL"
-const CactusTokenWsteth CactusToken = "WSTETH"
-const CactusTokenWmaticPolygon CactusToken = "WMATIC_POLYGON"
-const CactusTokenWsolSol CactusToken = "WSOL_SOL"
-const CactusTokenUst CactusToken = "UST"
-const CactusTokenZec CactusToken = "ZEC"
-const CactusTokenGusd CactusToken = "GUSD"
-const CactusTokenUsdcoinArbitrum CactusToken = "USDCOIN_ARBITRUM"
-const CactusTokenWbtcArbitrum CactusToken = "WBTC_ARBITRUM"
-const CactusTokenWbnbBsc CactusToken = "WBNB_BSC"
-const CactusTokenCgptBsc CactusToken = "CGPT_BSC"
-const CactusTokenAgix CactusToken = "AGIX"
-const CactusTokenAudio CactusToken = "AUDIO"
-const CactusTokenKishu CactusToken = "KISHU"
-const CactusTokenSomm CactusToken = "SOMM"
-const CactusTokenStbt CactusToken = "STBT"
-const CactusTokenUsdcPolygon CactusToken = "USDC_POLYGON"
-const CactusTokenUsdtPolygon CactusToken = "USDT_POLYGON"
-const CactusTokenXrp CactusToken = "XRP"
-const CactusTokenEthZksync CactusToken = "ETH_ZKSYNC"
-const CactusTokenUsdcZksync CactusToken = "USDC_ZKSYNC"
+
+const (
+	CactusToken3crv                CactusToken = "3CRV"
+	CactusTokenAave                CactusToken = "AAVE"
+	CactusTokenAda                 CactusToken = "ADA"
+	CactusTokenAgix                CactusToken = "AGIX"
+	CactusTokenAidogeArbitrum      CactusToken = "AIDOGE_ARBITRUM"
+	CactusTokenAnkr                CactusToken = "ANKR"
+	CactusTokenArbArbitrum         CactusToken = "ARB_ARBITRUM"
+	CactusTokenAudio               CactusToken = "AUDIO"
+	CactusTokenAvaxAvax            CactusToken = "AVAX_AVAX"
+	CactusTokenAvdo                CactusToken = "AVDO"
+	CactusTokenBadger              CactusToken = "BADGER"
+	CactusTokenBat                 CactusToken = "BAT"
+	CactusTokenBbtc                CactusToken = "BBTC"
+	CactusTokenBch                 CactusToken = "BCH"
+	CactusTokenBcha                CactusToken = "BCHA"
+	CactusTokenBchSmartbch         CactusToken = "BCH_SMARTBCH"
+	CactusTokenBcrvrenwbtc         CactusToken = "BCRVRENWBTC"
+	CactusTokenBethBsc             CactusToken = "BETH_BSC"
+	CactusTokenBit                 CactusToken = "BIT"
+	CactusTokenBnbBsc              CactusToken = "BNB_BSC"
+	CactusTokenBnt                 CactusToken = "BNT"
+	CactusTokenBond                CactusToken = "BOND"
+	CactusTokenBooFantom           CactusToken = "BOO_FANTOM"
+	CactusTokenBoraKlay            CactusToken = "BORA_KLAY"
+	CactusTokenBtc                 CactusToken = "BTC"
+	CactusTokenBtcSol              CactusToken = "BTC_SOL"
+	CactusTokenBtm                 CactusToken = "BTM"
+	CactusTokenBtmn                CactusToken = "BTMN"
+	CactusTokenBusd                CactusToken = "BUSD"
+	CactusTokenCakeBsc             CactusToken = "CAKE_BSC"
+	CactusTokenCcbtc               CactusToken = "CCBTC"
+	CactusTokenCdaicusdc           CactusToken = "CDAICUSDC"
+	CactusTokenCel                 CactusToken = "CEL"
+	CactusTokenCeth                CactusToken = "CETH"
+	CactusTokenCgptBsc             CactusToken = "CGPT_BSC"
+	CactusTokenChad                CactusToken = "CHAD"
+	CactusTokenChf                 CactusToken = "CHF"
+	CactusTokenChsb                CactusToken = "CHSB"
+	CactusTokenChz                 CactusToken = "CHZ"
+	CactusTokenComp                CactusToken = "COMP"
+	CactusTokenCrv                 CactusToken = "CRV"
+	CactusTokenCrv3crypto          CactusToken = "CRV3CRYPTO"
+	CactusTokenCrvplain3andsusd    CactusToken = "CRVPLAIN3ANDSUSD"
+	CactusTokenCrvrenwbtc          CactusToken = "CRVRENWBTC"
+	CactusTokenCusdc               CactusToken = "CUSDC"
+	CactusTokenCusdt               CactusToken = "CUSDT"
+	CactusTokenCwbtc               CactusToken = "CWBTC"
+	CactusTokenD2d                 CactusToken = "D2D"
+	CactusTokenDai                 CactusToken = "DAI"
+	CactusTokenDaiArbitrum         CactusToken = "DAI_ARBITRUM"
+	CactusTokenDaiOptimism         CactusToken = "DAI_OPTIMISM"
+	CactusTokenDash                CactusToken = "DASH"
+	CactusTokenDcr                 CactusToken = "DCR"
+	CactusTokenDf                  CactusToken = "DF"
+	CactusTokenDoge                CactusToken = "DOGE"
+	CactusTokenDot                 CactusToken = "DOT"
+	CactusTokenEben                CactusToken = "EBEN"
+	CactusTokenEnj                 CactusToken = "ENJ"
+	CactusTokenEtc                 CactusToken = "ETC"
+	CactusTokenEth                 CactusToken = "ETH"
+	CactusTokenEthArbitrum         CactusToken = "ETH_ARBITRUM"
+	CactusTokenEthBsc              CactusToken = "ETH_BSC"
+	CactusTokenEthEtf              CactusToken = "ETH_ETF"
+	CactusTokenEthEthw             CactusToken = "ETH_ETHW"
+	CactusTokenEthOptimism         CactusToken = "ETH_OPTIMISM"
+	CactusTokenEthZksync           CactusToken = "ETH_ZKSYNC"
+	CactusTokenEvry                CactusToken = "EVRY"
+	CactusTokenFbtcFantom          CactusToken = "FBTC_FANTOM"
+	CactusTokenFethFantom          CactusToken = "FETH_FANTOM"
+	CactusTokenFil                 CactusToken = "FIL"
+	CactusTokenFly                 CactusToken = "FLY"
+	CactusTokenFtmFantom           CactusToken = "FTM_FANTOM"
+	CactusTokenFtt                 CactusToken = "FTT"
+	CactusTokenFwb                 CactusToken = "FWB"
+	CactusTokenGal                 CactusToken = "GAL"
+	CactusTokenGeneSol             CactusToken = "GENE_SOL"
+	CactusTokenGrt                 CactusToken = "GRT"
+	CactusTokenGusd                CactusToken = "GUSD"
+	CactusTokenHbtc                CactusToken = "HBTC"
+	CactusTokenHcrv                CactusToken = "HCRV"
+	CactusTokenHigh                CactusToken = "HIGH"
+	CactusTokenHot                 CactusToken = "HOT"
+	CactusTokenHt                  CactusToken = "HT"
+	CactusTokenHtHeco              CactusToken = "HT_HECO"
+	CactusTokenHusd                CactusToken = "HUSD"
+	CactusTokenImbtc               CactusToken = "IMBTC"
+	CactusTokenJoeAvax             CactusToken = "JOE_AVAX"
+	CactusTokenKishu               CactusToken = "KISHU"
+	CactusTokenKlayKlay            CactusToken = "KLAY_KLAY"
+	CactusTokenKsm                 CactusToken = "KSM"
+	CactusTokenLeash               CactusToken = "LEASH"
+	CactusTokenLeo                 CactusToken = "LEO"
+	CactusTokenLink                CactusToken = "LINK"
+	CactusTokenLon                 CactusToken = "LON"
+	CactusTokenLtc                 CactusToken = "LTC"
+	CactusTokenLusd                CactusToken = "LUSD"
+	CactusTokenMana                CactusToken = "MANA"
+	CactusTokenMapsSol             CactusToken = "MAPS_SOL"
+	CactusTokenMatic               CactusToken = "MATIC"
+	CactusTokenMaticPolygon        CactusToken = "MATIC_POLYGON"
+	CactusTokenMim                 CactusToken = "MIM"
+	CactusTokenMimArbitrum         CactusToken = "MIM_ARBITRUM"
+	CactusTokenMkr                 CactusToken = "MKR"
+	CactusTokenMnt                 CactusToken = "MNT"
+	CactusTokenNear                CactusToken = "NEAR"
+	CactusTokenNexo                CactusToken = "NEXO"
+	CactusTokenNftx                CactusToken = "NFTX"
+	CactusTokenOkb                 CactusToken = "OKB"
+	CactusTokenOmg                 CactusToken = "OMG"
+	CactusTokenOxySol              CactusToken = "OXY_SOL"
+	CactusTokenPepe                CactusToken = "PEPE"
+	CactusTokenPngAvax             CactusToken = "PNG_AVAX"
+	CactusTokenQiAvax              CactusToken = "QI_AVAX"
+	CactusTokenRdntArbitrum        CactusToken = "RDNT_ARBITRUM"
+	CactusTokenRev                 CactusToken = "REV"
+	CactusTokenSafuSol             CactusToken = "SAFU_SOL"
+	CactusTokenShib                CactusToken = "SHIB"
+	CactusTokenSlndSol             CactusToken = "SLND_SOL"
+	CactusTokenSnx                 CactusToken = "SNX"
+	CactusTokenSoethSol            CactusToken = "SOETH_SOL"
+	CactusTokenSol                 CactusToken = "SOL"
+	CactusTokenSomm                CactusToken = "SOMM"
+	CactusTokenSpiritFantom        CactusToken = "SPIRIT_FANTOM"
+	CactusTokenSrmSol              CactusToken = "SRM_SOL"
+	CactusTokenStbt                CactusToken = "STBT"
+	CactusTokenStecrv              CactusToken = "STECRV"
+	CactusTokenSteth               CactusToken = "STETH"
+	CactusTokenSusd                CactusToken = "SUSD"
+	CactusTokenSushi               CactusToken = "SUSHI"
+	CactusTokenSweatNear           CactusToken = "SWEAT_NEAR"
+	CactusTokenTel                 CactusToken = "TEL"
+	CactusTokenTombFantom          CactusToken = "TOMB_FANTOM"
+	CactusTokenTonTon              CactusToken = "TON_TON"
+	CactusTokenTrx                 CactusToken = "TRX"
+	CactusTokenTusd                CactusToken = "TUSD"
+	CactusTokenUma                 CactusToken = "UMA"
+	CactusTokenUni                 CactusToken = "UNI"
+	CactusTokenUniv2usdc3          CactusToken = "UNIV2USDC3"
+	CactusTokenUniv2usdt2          CactusToken = "UNIV2USDT2"
+	CactusTokenUniv2wbtc2          CactusToken = "UNIV2WBTC2"
+	CactusTokenUsdc                CactusToken = "USDC"
+	CactusTokenUsdcEAvax           CactusToken = "USDC.E_AVAX"
+	CactusTokenUsdcoinArbitrum     CactusToken = "USDCOIN_ARBITRUM"
+	CactusTokenUsdcArbitrum        CactusToken = "USDC_ARBITRUM"
+	CactusTokenUsdcAvax            CactusToken = "USDC_AVAX"
+	CactusTokenUsdcBsc             CactusToken = "USDC_BSC"
+	CactusTokenUsdcFantom          CactusToken = "USDC_FANTOM"
+	CactusTokenUsdcNear            CactusToken = "USDC_NEAR"
+	CactusTokenUsdcOptimism        CactusToken = "USDC_OPTIMISM"
+	CactusTokenUsdcPolygon         CactusToken = "USDC_POLYGON"
+	CactusTokenUsdcSol             CactusToken = "USDC_SOL"
+	CactusTokenUsdcTrc             CactusToken = "USDC_TRC"
+	CactusTokenUsdcZksync          CactusToken = "USDC_ZKSYNC"
+	CactusTokenUsdd                CactusToken = "USDD"
+	CactusTokenUsdp                CactusToken = "USDP"
+	CactusTokenUsdterc             CactusToken = "USDTERC"
+	CactusTokenUsdtomni            CactusToken = "USDTOMNI"
+	CactusTokenUsdttrc             CactusToken = "USDTTRC"
+	CactusTokenUsdtArbitrum        CactusToken = "USDT_ARBITRUM"
+	CactusTokenUsdtAvax            CactusToken = "USDT_AVAX"
+	CactusTokenUsdtBsc             CactusToken = "USDT_BSC"
+	CactusTokenUsdtNear            CactusToken = "USDT_NEAR"
+	CactusTokenUsdtOptimism        CactusToken = "USDT_OPTIMISM"
+	CactusTokenUsdtPolygon         CactusToken = "USDT_POLYGON"
+	CactusTokenUsdtSol             CactusToken = "USDT_SOL"
+	CactusTokenUst                 CactusToken = "UST"
+	CactusTokenVelo                CactusToken = "VELO"
+	CactusTokenWbnbBsc             CactusToken = "WBNB_BSC"
+	CactusTokenWbtc                CactusToken = "WBTC"
+	CactusTokenWbtcArbitrum        CactusToken = "WBTC_ARBITRUM"
+	CactusTokenWbtcOptimism        CactusToken = "WBTC_OPTIMISM"
+	CactusTokenWeth                CactusToken = "WETH"
+	CactusTokenWethOptimism        CactusToken = "WETH_OPTIMISM"
+	CactusTokenWmaticPolygon       CactusToken = "WMATIC_POLYGON"
+	CactusTokenWojak               CactusToken = "WOJAK"
+	CactusTokenWoo                 CactusToken = "WOO"
+	CactusTokenWsolSol             CactusToken = "WSOL_SOL"
+	CactusTokenWsteth              CactusToken = "WSTETH"
+	CactusTokenXlm                 CactusToken = "XLM"
+	CactusTokenXrp                 CactusToken = "XRP"
+	CactusTokenYdaiyusdcyusdtybusd CactusToken = "YDAIYUSDCYUSDTYBUSD"
+	CactusTokenYdaiyusdcyusdtytusd CactusToken = "YDAIYUSDCYUSDTYTUSD"
+	CactusTokenZec                 CactusToken = "ZEC"
+	CactusTokenZrx                 CactusToken = "ZRX"
+)
@@ -0,0 +1,79 @@
+package ethbind
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+)
+
+var (
+	_ bind.ContractTransactor = (*Transactor)(nil)
+	_ bind.DeployBackend      = (*Transactor)(nil)
+)
+
+// placeholderCode is reported as the code of every contract, cactus can not read chain state and
+// bindings refuse to estimate gas for a contract without code
+var placeholderCode = []byte{0x00}
+
+// HeaderByNumber returns an empty header, with a zero base fee on EIP-1559 chains
+// The binding then builds a dynamic fee transaction there and a legacy one elsewhere.
+func (t *Transactor) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	eip1559, err := t.Client.Registry().SupportsEIP1559(ctx, t.Chain)
+	if err != nil {
+		return nil, err
+	}
+	header := &types.Header{Number: number}
+	if eip1559 {
+		header.BaseFee = new(big.Int)
+	}
+	return header, nil
+}
+
+// PendingCodeAt reports every contract as deployed, the order fails with cactus if it is not
+func (t *Transactor) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return placeholderCode, nil
+}
+
+// PendingNonceAt returns 0, cactus assigns the nonce
+func (t *Transactor) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return 0, nil
+}
+
+// SuggestGasPrice returns 0, which leaves the gas price to cactus
+func (t *Transactor) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+// SuggestGasTipCap returns 0, which leaves the fees to cactus
+// Set both GasFeeCap and GasTipCap or neither, with only the tip the fee cap is built from a zero base fee.
+func (t *Transactor) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return new(big.Int), nil
+}
+
+// EstimateGas returns 0, which leaves the gas limit to cactus
+func (t *Transactor) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 0, nil
+}
+
+// TransactionReceipt returns the receipt of a transaction sent through the transactor
+// txHash is the hash of the transaction the binding returned. The receipt only carries the on chain
+// TxHash, Status and EffectiveGasPrice, read block and logs from a node with that hash.
+// Receipts are kept for the life of the transactor.
+func (t *Transactor) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	t.mu.Lock()
+	receipt, ok := t.receipts[txHash]
+	t.mu.Unlock()
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+// CodeAt is not supported, contract orders can not deploy contracts
+func (t *Transactor) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, errors.New("ethbind: contract orders can not read chain state")
+}
@@ -0,0 +1,4 @@
+// Package erc20 is an abigen binding of the ERC20 transfer, used to test Transactor with generated code
+package erc20
+
+//go:generate go run github.com/ethereum/go-ethereum/cmd/abigen@v1.14.13 --abi erc20.abi --pkg erc20 --type ERC20 --out erc20.go
@@ -0,0 +1,252 @@
+// Package ethbind lets abigen bindings send transactions through cactus contract orders
+//
+// Transactor is a bind.ContractTransactor: pass it to a NewXxxTransactor binding and call the
+// binding with Transactor.TransactOpts. Every call creates a contract order of the transactor's DEFI
+// wallet and returns once cactus has sent the transaction, bind.WaitMined with the transactor then
+// gives the receipt carrying the on chain hash. The same binding code runs on a hot key with an
+// ethclient backend and bind.NewKeyedTransactorWithChainID.
+//
+// Cactus signs, prices and nonces the transactions itself, so the gas and nonce queries of the
+// binding are answered with zeros, which leave those values to cactus unless set in the options.
+//
+// ethbind is a separate module so the go-ethereum dependency stays out of the core sdk.
+package ethbind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/utils"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Contract order statuses documented by cactus, COMPLETED orders carry the tx hash
+const (
+	StatusProcessing = "PROCESSING"
+	StatusCompleted  = "COMPLETED"
+	StatusFailed     = "FAILED"
+	StatusCanceled   = "CANCELED"
+)
+
+const (
+	// DefaultPollInterval is how often a Transactor checks a pending order
+	DefaultPollInterval = 3 * time.Second
+	// DefaultNotFoundTimeout is how long a Transactor waits for a created order to become visible
+	DefaultNotFoundTimeout = time.Minute
+	// DefaultWaitTimeout is how long a Transactor waits for an order to end
+	DefaultWaitTimeout = 30 * time.Minute
+)
+
+var (
+	// ErrOrderFailed is returned when a contract order ends FAILED or CANCELED
+	ErrOrderFailed = errors.New("ethbind: contract order failed")
+	// ErrOrderPending is returned when a contract order has not ended within the wait timeout
+	ErrOrderPending = errors.New("ethbind: contract order still pending")
+)
+
+// Transactor sends transactions as contract orders of one cactus DEFI wallet address
+type Transactor struct {
+	Client     *cactus.Cactus
+	BId        string
+	WalletCode string
+	// From is the wallet address the orders are sent from, the only sender TransactOpts signs for
+	From  common.Address
+	Chain constants.ChainName
+	// GasPriceLevel is used when the options set no gas price, empty leaves it to cactus
+	GasPriceLevel string
+	// Description is attached to every order
+	Description string
+	// PollInterval between order checks, 0 uses DefaultPollInterval
+	PollInterval time.Duration
+	// NotFoundTimeout bounds how long an order may stay invisible, 0 uses DefaultNotFoundTimeout
+	NotFoundTimeout time.Duration
+	// WaitTimeout bounds how long an order may stay pending, 0 uses DefaultWaitTimeout
+	WaitTimeout time.Duration
+	// OrderNo generates the order number of each transaction, nil uses a random one
+	// The order number is fixed before the first attempt, so the client retries create orders safely.
+	OrderNo func() string
+
+	mu       sync.Mutex
+	receipts map[common.Hash]*types.Receipt // by the hash of the transaction the binding built
+}
+
+// NewTransactor creates a transactor sending from the address from of the DEFI wallet walletCode on chain
+func NewTransactor(client *cactus.Cactus, bId string, walletCode string, from common.Address, chain constants.ChainName) *Transactor {
+	return &Transactor{Client: client, BId: bId, WalletCode: walletCode, From: from, Chain: chain}
+}
+
+// TransactOpts returns binding options sending from the wallet address
+// The signer passes the transaction on unsigned, cactus signs it when the order is processed.
+// Set Value, GasLimit, GasPrice or both of GasFeeCap and GasTipCap on the result as needed.
+func (t *Transactor) TransactOpts(ctx context.Context) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From: t.From,
+		Signer: func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if from != t.From {
+				return nil, fmt.Errorf("ethbind: can not send from %s, the transactor sends from %s", from.Hex(), t.From.Hex())
+			}
+			return tx, nil
+		},
+		Context: ctx,
+	}
+}
+
+// SendTransaction creates a contract order for tx and waits for it to complete
+// The on chain hash differs from tx.Hash, as cactus signs the transaction, look it up with
+// TransactionReceipt or bind.WaitMined. Cancel ctx to stop waiting, the order itself stays with cactus.
+func (t *Transactor) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	req, err := t.request(tx)
+	if err != nil {
+		return err
+	}
+	created, err := t.Client.CreateContractOrderWithContext(ctx, t.BId, req)
+	if err != nil {
+		return err
+	}
+	orderNo := created.Data.OrderNo
+	if orderNo == "" {
+		orderNo = req.OrderNo
+	}
+	order, err := t.Wait(ctx, orderNo)
+	if err != nil {
+		return err
+	}
+	hash, err := parseHash(order.TxId)
+	if err != nil {
+		return fmt.Errorf("ethbind: order %s: %w", orderNo, err)
+	}
+	receipt := &types.Receipt{
+		Type:              tx.Type(),
+		Status:            types.ReceiptStatusSuccessful,
+		TxHash:            hash,
+		EffectiveGasPrice: order.GasPrice.Big(),
+	}
+	t.mu.Lock()
+	if t.receipts == nil {
+		t.receipts = map[common.Hash]*types.Receipt{}
+	}
+	t.receipts[tx.Hash()] = receipt
+	t.mu.Unlock()
+	return nil
+}
+
+// request maps a transaction built by a binding onto a contract order
+func (t *Transactor) request(tx *types.Transaction) (cactus.CreateContractOrderReq, error) {
+	var req cactus.CreateContractOrderReq
+	switch {
+	case tx.To() == nil:
+		return req, errors.New("ethbind: contract creation is not supported by contract orders")
+	case tx.Nonce() != 0:
+		return req, errors.New("ethbind: cactus assigns the nonce, leave Nonce nil")
+	case len(tx.AccessList()) > 0:
+		return req, errors.New("ethbind: access lists are not supported by contract orders")
+	case tx.Type() != types.LegacyTxType && tx.Type() != types.DynamicFeeTxType:
+		return req, fmt.Errorf("ethbind: transaction type %d is not supported", tx.Type())
+	case tx.Gas() > uint64(int(^uint(0)>>1)):
+		return req, fmt.Errorf("ethbind: gas limit %d is too large", tx.Gas())
+	case tx.Value().Sign() < 0:
+		return req, fmt.Errorf("ethbind: negative value %s", tx.Value())
+	}
+	req = cactus.CreateContractOrderReq{
+		FromWalletCode: t.WalletCode,
+		FromAddress:    strings.ToLower(t.From.Hex()),
+		ToAddress:      strings.ToLower(tx.To().Hex()),
+		Amount:         cactus.NewAmountFromBig(tx.Value()),
+		Chain:          t.Chain,
+		ContractData:   hexutil.Encode(tx.Data()),
+		GasLimit:       int(tx.Gas()),
+		Description:    t.Description,
+	}
+	var err error
+	if tx.Type() == types.DynamicFeeTxType {
+		if req.MaxFeePerGas, err = wei("GasFeeCap", tx.GasFeeCap()); err != nil {
+			return req, err
+		}
+		if req.MaxPriorityFeePerGas, err = wei("GasTipCap", tx.GasTipCap()); err != nil {
+			return req, err
+		}
+	} else if req.GasPrice, err = wei("GasPrice", tx.GasPrice()); err != nil {
+		return req, err
+	}
+	if req.GasPrice.IsZero() && req.MaxFeePerGas.IsZero() && req.MaxPriorityFeePerGas.IsZero() {
+		req.GasPriceLevel = t.GasPriceLevel
+	}
+	if t.OrderNo != nil {
+		req.OrderNo = t.OrderNo()
+	} else {
+		req.OrderNo = strings.ReplaceAll(utils.GenerateUuid(), "-", "")
+	}
+	return req, nil
+}
+
+// wei converts a gas price to an amount, nil is 0
+func wei(name string, x *big.Int) (cactus.Amount, error) {
+	if x != nil && x.Sign() < 0 {
+		return cactus.Amount{}, fmt.Errorf("ethbind: negative %s %s", name, x)
+	}
+	return cactus.NewAmountFromBig(x), nil
+}
+
+// parseHash parses the tx hash cactus reports, unlike common.HexToHash it refuses anything but 32 bytes
+func parseHash(s string) (common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("%q is not a transaction hash", s)
+	}
+	return common.BytesToHash(b), nil
+}
+
+// Wait polls a contract order until it is COMPLETED with a tx hash, or returns ErrOrderFailed when
+// it ends FAILED or CANCELED. An order not visible within NotFoundTimeout returns cactus.ErrOrderNotFound,
+// one with any other status after WaitTimeout returns ErrOrderPending. The order stays with cactus either way.
+func (t *Transactor) Wait(ctx context.Context, orderNo string) (cactus.ContractOrder, error) {
+	interval := orDefault(t.PollInterval, DefaultPollInterval)
+	notFound := orDefault(t.NotFoundTimeout, DefaultNotFoundTimeout)
+	pending := orDefault(t.WaitTimeout, DefaultWaitTimeout)
+	start := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return cactus.ContractOrder{}, ctx.Err()
+		case <-timer.C:
+		}
+		resp, err := t.Client.GetDefiTransactionDetailsWithContext(ctx, t.BId, t.WalletCode, orderNo)
+		switch {
+		case errors.Is(err, cactus.ErrOrderNotFound):
+			if time.Since(start) >= notFound {
+				return cactus.ContractOrder{}, fmt.Errorf("ethbind: order %s not visible after %s: %w", orderNo, notFound, err)
+			}
+		case err != nil:
+			return cactus.ContractOrder{}, err
+		case resp.Data.Status == StatusCompleted:
+			if resp.Data.TxId == "" {
+				return resp.Data, fmt.Errorf("ethbind: order %s completed without a tx hash", orderNo)
+			}
+			return resp.Data, nil
+		case resp.Data.Status == StatusFailed, resp.Data.Status == StatusCanceled:
+			return resp.Data, fmt.Errorf("%w: order %s is %s", ErrOrderFailed, orderNo, resp.Data.Status)
+		case time.Since(start) >= pending:
+			return resp.Data, fmt.Errorf("%w: order %s is %s after %s", ErrOrderPending, orderNo, resp.Data.Status, pending)
+		}
+		timer.Reset(interval)
+	}
+}
+
+// orDefault returns d, or def when d is not positive
+func orDefault(d time.Duration, def time.Duration) time.Duration {
+	if d <= 0 {
+		return def
+	}
+	return d
+}
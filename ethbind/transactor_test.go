@@ -0,0 +1,153 @@
+package ethbind
+
+import (
+	"context"
+	"errors"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactustest"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/constants"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/ethbind/internal/erc20"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	usdt = common.HexToAddress("0xdac17f958d2ee523a2206206994597c13d831ec7")
+	from = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	to   = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+)
+
+func newTransactor(t *testing.T) (*cactustest.Server, *Transactor, *erc20.ERC20Transactor) {
+	t.Helper()
+	srv := cactustest.NewServer()
+	t.Cleanup(srv.Close)
+	client := srv.NewClient()
+	codes, err := client.CreateWallet(cactustest.DefaultBId, "DEFI", 1)
+	if err != nil {
+		t.Fatalf("CreateWallet() error = %v", err)
+	}
+	tr := NewTransactor(client, cactustest.DefaultBId, codes.Data[0], from, constants.ChainNameETH)
+	tr.PollInterval = 10 * time.Millisecond
+	tr.OrderNo = func() string { return "call-" + t.Name() }
+	token, err := erc20.NewERC20Transactor(usdt, tr)
+	if err != nil {
+		t.Fatalf("NewERC20Transactor() error = %v", err)
+	}
+	return srv, tr, token
+}
+
+// settle waits for the order to be created and ends it with end
+func settle(t *testing.T, srv *cactustest.Server, orderNo string, end func(bId string, orderNo string) (cactustest.Order, error)) <-chan cactustest.Order {
+	settled := make(chan cactustest.Order, 1)
+	go func() {
+		for {
+			if _, ok := srv.Order(cactustest.DefaultBId, orderNo); ok {
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		o, err := end(cactustest.DefaultBId, orderNo)
+		if err != nil {
+			t.Error(err)
+		}
+		settled <- o
+	}()
+	return settled
+}
+
+func TestTransfer(t *testing.T) {
+	srv, tr, token := newTransactor(t)
+	settled := settle(t, srv, "call-TestTransfer", srv.CompleteOrder)
+	opts := tr.TransactOpts(context.Background())
+	opts.GasFeeCap = big.NewInt(30_000_000_000)
+	opts.GasTipCap = big.NewInt(1_000_000_000)
+	opts.GasLimit = 60000
+	tx, err := token.Transfer(opts, to, big.NewInt(7))
+	if err != nil {
+		t.Fatalf("Transfer() error = %v", err)
+	}
+	order := <-settled
+	if tx.Type() != types.DynamicFeeTxType {
+		t.Errorf("Transfer() type = %d, want a dynamic fee transaction on an EIP-1559 chain", tx.Type())
+	}
+	receipt, err := bind.WaitMined(context.Background(), tr, tx)
+	if err != nil {
+		t.Fatalf("WaitMined() error = %v", err)
+	}
+	if receipt.TxHash.Hex() != strings.ToLower(order.TxHash) || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Errorf("WaitMined() = %s %d, want %s", receipt.TxHash.Hex(), receipt.Status, order.TxHash)
+	}
+	stored, _ := srv.Order(cactustest.DefaultBId, order.OrderNo)
+	if stored.FromAddress != strings.ToLower(from.Hex()) || stored.ContractAddress != strings.ToLower(usdt.Hex()) ||
+		!strings.HasPrefix(stored.ContractData, "0xa9059cbb") || stored.GasPrice != 30_000_000_000 || stored.GasLimit != 60000 {
+		t.Errorf("order = %+v, want the transaction mapped onto it", stored)
+	}
+}
+
+func TestTransferFailedOrder(t *testing.T) {
+	srv, tr, token := newTransactor(t)
+	settle(t, srv, "call-TestTransferFailedOrder", srv.FailOrder)
+	tx, err := token.Transfer(tr.TransactOpts(context.Background()), to, big.NewInt(7))
+	if !errors.Is(err, ErrOrderFailed) || !strings.Contains(err.Error(), StatusFailed) {
+		t.Errorf("Transfer() error = %v, want %v", err, ErrOrderFailed)
+	}
+	if tx != nil {
+		t.Errorf("Transfer() = %v, want no transaction", tx)
+	}
+}
+
+func TestWaitTimeouts(t *testing.T) {
+	_, tr, token := newTransactor(t)
+	tr.NotFoundTimeout = 30 * time.Millisecond
+	tr.WaitTimeout = 30 * time.Millisecond
+	if _, err := tr.Wait(context.Background(), "missing"); !errors.Is(err, cactus.ErrOrderNotFound) {
+		t.Errorf("Wait() on a missing order error = %v, want %v", err, cactus.ErrOrderNotFound)
+	}
+	_, err := token.Transfer(tr.TransactOpts(context.Background()), to, big.NewInt(7))
+	if !errors.Is(err, ErrOrderPending) || !strings.Contains(err.Error(), StatusProcessing) {
+		t.Errorf("Transfer() on an unsettled order error = %v, want %v", err, ErrOrderPending)
+	}
+}
+
+func TestTransferRejectsOptions(t *testing.T) {
+	_, tr, token := newTransactor(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := map[string]func(opts *bind.TransactOpts){
+		"nonce":           func(opts *bind.TransactOpts) { opts.Nonce = big.NewInt(1) },
+		"other sender":    func(opts *bind.TransactOpts) { opts.From = to },
+		"mixed gas price": func(opts *bind.TransactOpts) { opts.GasPrice, opts.GasTipCap = big.NewInt(1), big.NewInt(1) },
+		"canceled":        func(opts *bind.TransactOpts) { opts.Context = ctx },
+	}
+	for name, edit := range tests {
+		opts := tr.TransactOpts(context.Background())
+		edit(opts)
+		if _, err := token.Transfer(opts, to, big.NewInt(7)); err == nil {
+			t.Errorf("%s: Transfer() error = nil", name)
+		}
+	}
+}
+
+func TestTransactionReceiptNotFound(t *testing.T) {
+	_, tr, _ := newTransactor(t)
+	if _, err := tr.TransactionReceipt(context.Background(), common.Hash{}); err == nil {
+		t.Error("TransactionReceipt() of an unknown transaction error = nil")
+	}
+}
+
+func TestParseHash(t *testing.T) {
+	h, err := parseHash("0x" + strings.Repeat("ab", 32))
+	if err != nil || h.Hex() != "0x"+strings.Repeat("ab", 32) {
+		t.Errorf("parseHash() = %s, %v", h, err)
+	}
+	for _, bad := range []string{"0x1234", strings.Repeat("ab", 32), "0x" + strings.Repeat("zz", 32)} {
+		if _, err = parseHash(bad); err == nil {
+			t.Errorf("parseHash(%q) error = nil", bad)
+		}
+	}
+}
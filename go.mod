@@ -1,3 +1,3 @@
 module github.com/DenrianWeiss/cactus-wallet-sdk
 
-go 1.19
+go 1.23
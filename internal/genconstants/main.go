@@ -0,0 +1,262 @@
+// Command genconstants generates constants/tokens.go and constants/chains.go
+//
+// It reads the coin-infos and chain-infos from a json snapshot, or with -live from the api of the
+// profile selected by $CACTUS_CONFIG and $CACTUS_PROFILE, or the CACTUS_* variables, in which case
+// the snapshot is rewritten once the code is.
+// Two symbols mapping to the same Go identifier abort the generation. The Tokens and Chains metadata
+// is generated only from a snapshot that has it, and then every coin and chain must carry it.
+//
+//	go run ./internal/genconstants -snapshot constants/snapshot.json -out constants [-live]
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"github.com/DenrianWeiss/cactus-wallet-sdk/config"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Snapshot is the data the constants are generated from, the data fields of both endpoints
+type Snapshot struct {
+	CoinInfos  []cactus.CoinInfo  `json:"coin_infos"`
+	ChainInfos []cactus.ChainInfo `json:"chain_infos"`
+}
+
+func main() {
+	snapshotPath := flag.String("snapshot", "snapshot.json", "json snapshot to read, or to write with -live")
+	out := flag.String("out", ".", "directory tokens.go and chains.go are written to")
+	live := flag.Bool("live", false, "fetch the snapshot from the api of the profile selected by the CACTUS_* environment variables")
+	flag.Parse()
+	if err := run(*snapshotPath, *out, *live); err != nil {
+		fmt.Fprintln(os.Stderr, "genconstants:", err)
+		os.Exit(1)
+	}
+}
+
+func run(snapshotPath string, out string, live bool) error {
+	if live {
+		fetched, err := fetch(context.Background())
+		if err != nil {
+			return err
+		}
+		return generate(fetched, out, snapshotPath)
+	}
+	data, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		return err
+	}
+	var snapshot Snapshot
+	if err = json.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("%s: %w", snapshotPath, err)
+	}
+	return generate(&snapshot, out, "")
+}
+
+// generate renders tokens.go and chains.go into out, then rewrites the snapshot at snapshotPath if set
+// A snapshot that fails to generate is never written, so the committed one stays in sync with the code.
+func generate(snapshot *Snapshot, out string, snapshotPath string) error {
+	tokens, err := generateTokens(snapshot.CoinInfos)
+	if err != nil {
+		return err
+	}
+	chains, err := generateChains(snapshot.ChainInfos)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(out, "tokens.go"), tokens, 0o644); err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(out, "chains.go"), chains, 0o644); err != nil {
+		return err
+	}
+	if snapshotPath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(snapshotPath, append(data, '\n'), 0o644)
+}
+
+func fetch(ctx context.Context) (*Snapshot, error) {
+	profile, err := config.LoadProfile("", "")
+	if err != nil {
+		return nil, err
+	}
+	client, err := profile.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	coins, err := client.GetCoinInfoWithContext(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+	chains, err := client.GetChainInfoWithContext(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{CoinInfos: coins.Data, ChainInfos: chains.Data}, nil
+}
+
+// tokenIdent turns "USDC.E_AVAX" into "UsdcEAvax"
+func tokenIdent(symbol string) string {
+	var b strings.Builder
+	for _, part := range splitSymbol(symbol) {
+		b.WriteString(strings.ToUpper(part[:1]) + strings.ToLower(part[1:]))
+	}
+	return b.String()
+}
+
+// chainIdent turns "SMART-BCH" into "SMARTBCH"
+func chainIdent(chain string) string {
+	return strings.ToUpper(strings.Join(splitSymbol(chain), ""))
+}
+
+func splitSymbol(symbol string) []string {
+	return strings.FieldsFunc(symbol, func(r rune) bool {
+		return r > unicode.MaxASCII || !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// identifiers maps each name to its identifier, names that collide or have no identifier are an error
+// reserved identifiers are taken by hand written declarations.
+func identifiers(kind string, names []string, ident func(string) string, reserved ...string) (map[string]string, error) {
+	idents := map[string]string{}
+	owners := map[string]string{}
+	for _, id := range reserved {
+		owners[id] = "(reserved)"
+	}
+	var errs []string
+	for _, name := range names {
+		id := ident(name)
+		if id == "" {
+			errs = append(errs, fmt.Sprintf("%s %q has no usable characters", kind, name))
+			continue
+		}
+		if owner, ok := owners[id]; ok {
+			errs = append(errs, fmt.Sprintf("%s %q and %q both map to %s", kind, owner, name, id))
+			continue
+		}
+		owners[id] = name
+		idents[name] = id
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("name collision:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return idents, nil
+}
+
+func generateTokens(coins []cactus.CoinInfo) ([]byte, error) {
+	sort.Slice(coins, func(i, j int) bool { return coins[i].CactusSymbol < coins[j].CactusSymbol })
+	symbols := make([]string, len(coins))
+	for i, coin := range coins {
+		symbols[i] = coin.CactusSymbol
+	}
+	idents, err := identifiers("token", symbols, tokenIdent, "NotProvided")
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("type CactusToken string\n\n")
+	b.WriteString("const CactusTokenNotProvided CactusToken = \"\"\n\n")
+	b.WriteString("const (\n")
+	for _, coin := range coins {
+		fmt.Fprintf(&b, "\tCactusToken%s CactusToken = %q\n", idents[coin.CactusSymbol], coin.CactusSymbol)
+	}
+	b.WriteString(")\n")
+	if !slices.ContainsFunc(coins, func(coin cactus.CoinInfo) bool { return coin.Decimals != "" }) {
+		return format.Source(b.Bytes())
+	}
+	b.WriteString(`
+// TokenInfo is the metadata GetCoinInfo reported for a token when the snapshot was taken
+type TokenInfo struct {
+	Chain           ChainName
+	Decimals        int
+	ContractAddress string
+}
+
+// Tokens holds the metadata of every token
+var Tokens = map[CactusToken]TokenInfo{
+`)
+	for _, coin := range coins {
+		if coin.Decimals == "" {
+			return nil, fmt.Errorf("token %q: no decimals in a snapshot with metadata", coin.CactusSymbol)
+		}
+		decimals, err := strconv.Atoi(coin.Decimals)
+		if err != nil {
+			return nil, fmt.Errorf("token %q: invalid decimals %q", coin.CactusSymbol, coin.Decimals)
+		}
+		chain := coin.CactusChain
+		if chain == "" {
+			chain = coin.Chain
+		}
+		fmt.Fprintf(&b, "\tCactusToken%s: {Chain: %q, Decimals: %d, ContractAddress: %q},\n",
+			idents[coin.CactusSymbol], chain, decimals, coin.ContractAddress)
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+func generateChains(chains []cactus.ChainInfo) ([]byte, error) {
+	sort.Slice(chains, func(i, j int) bool { return chains[i].Chain < chains[j].Chain })
+	names := make([]string, len(chains))
+	for i, chain := range chains {
+		names[i] = chain.Chain
+	}
+	idents, err := identifiers("chain", names, chainIdent)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("type ChainName string\n\n")
+	b.WriteString("const (\n")
+	for _, chain := range chains {
+		fmt.Fprintf(&b, "\tChainName%s ChainName = %q\n", idents[chain.Chain], chain.Chain)
+	}
+	b.WriteString(")\n")
+	if !slices.ContainsFunc(chains, func(chain cactus.ChainInfo) bool { return chain.MainCoin != "" }) {
+		return format.Source(b.Bytes())
+	}
+	b.WriteString(`
+// ChainInfo is the metadata GetChainInfo reported for a chain when the snapshot was taken
+type ChainInfo struct {
+	FullName       string
+	MainCoin       CactusToken
+	EVM            bool
+	EIP1559        bool
+	Confirmations  int
+}
+
+// Chains holds the metadata of every chain
+var Chains = map[ChainName]ChainInfo{
+`)
+	for _, chain := range chains {
+		if chain.MainCoin == "" {
+			return nil, fmt.Errorf("chain %q: no main coin in a snapshot with metadata", chain.Chain)
+		}
+		fmt.Fprintf(&b, "\tChainName%s: {FullName: %q, MainCoin: %q, EVM: %t, EIP1559: %t, Confirmations: %d},\n",
+			idents[chain.Chain], chain.FullName, chain.MainCoin, chain.EvmChain, chain.SupportEip1559, chain.ConfirmBlockNumber)
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
+
+const header = `// Code generated by genconstants; DO NOT EDIT.
+
+package constants
+
+`
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"github.com/DenrianWeiss/cactus-wallet-sdk/cactus"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIdentifiers(t *testing.T) {
+	for symbol, want := range map[string]string{"USDC.E_AVAX": "UsdcEAvax", "BNB_BSC": "BnbBsc", "3CRV": "3crv"} {
+		if got := tokenIdent(symbol); got != want {
+			t.Errorf("tokenIdent(%q) = %q, want %q", symbol, got, want)
+		}
+	}
+	if got := chainIdent("SMART-BCH"); got != "SMARTBCH" {
+		t.Errorf("chainIdent() = %q, want SMARTBCH", got)
+	}
+}
+
+func TestGenerateTokens(t *testing.T) {
+	src, err := generateTokens([]cactus.CoinInfo{
+		{CactusSymbol: "USDT_ETH", CactusChain: "ETH", Decimals: "6", ContractAddress: "0xdac17f958d2ee523a2206206994597c13d831ec7"},
+		{CactusSymbol: "BTC", CactusChain: "BTC", Decimals: "8"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`CactusTokenBtc     CactusToken = "BTC"`,
+		`CactusTokenUsdtEth: {Chain: "ETH", Decimals: 6, ContractAddress: "0xdac17f958d2ee523a2206206994597c13d831ec7"}`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated source misses %s:\n%s", want, src)
+		}
+	}
+}
+
+func TestGenerateWithoutMetadata(t *testing.T) {
+	src, err := generateTokens([]cactus.CoinInfo{{CactusSymbol: "BTC"}, {CactusSymbol: "ETH"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(src), "Tokens") {
+		t.Errorf("generated metadata from a snapshot without any:\n%s", src)
+	}
+	_, err = generateTokens([]cactus.CoinInfo{{CactusSymbol: "BTC", Decimals: "8"}, {CactusSymbol: "ETH"}})
+	if err == nil || !strings.Contains(err.Error(), `"ETH"`) {
+		t.Errorf("generateTokens() error = %v, want ETH reported without decimals", err)
+	}
+	if _, err = generateChains([]cactus.ChainInfo{{Chain: "BTC", MainCoin: "BTC"}, {Chain: "ETH"}}); err == nil {
+		t.Error("generateChains() accepted a chain without main coin")
+	}
+}
+
+func TestGenerateFailsOnCollision(t *testing.T) {
+	_, err := generateTokens([]cactus.CoinInfo{{CactusSymbol: "USDC.E_AVAX"}, {CactusSymbol: "USDC_E_AVAX"}, {CactusSymbol: "NOT_PROVIDED"}})
+	if err == nil || !strings.Contains(err.Error(), `"USDC.E_AVAX" and "USDC_E_AVAX" both map to UsdcEAvax`) || !strings.Contains(err.Error(), "NotProvided") {
+		t.Errorf("generateTokens() error = %v, want both collisions reported", err)
+	}
+	if _, err = generateChains([]cactus.ChainInfo{{Chain: "SMART-BCH"}, {Chain: "SMARTBCH"}}); err == nil {
+		t.Error("generateChains() accepted colliding chains")
+	}
+}
+
+func TestGenerateKeepsSnapshotOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "snapshot.json")
+	if err := os.WriteFile(snapshotPath, []byte("{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fetched := &Snapshot{CoinInfos: []cactus.CoinInfo{{CactusSymbol: "USDC.E_AVAX"}, {CactusSymbol: "USDC_E_AVAX"}}}
+	if err := generate(fetched, dir, snapshotPath); err == nil {
+		t.Fatal("generate() accepted colliding symbols")
+	}
+	if data, _ := os.ReadFile(snapshotPath); string(data) != "{}\n" {
+		t.Errorf("snapshot rewritten after a failed generation:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tokens.go")); !os.IsNotExist(err) {
+		t.Errorf("tokens.go written after a failed generation, stat error = %v", err)
+	}
+
+	fetched.CoinInfos = fetched.CoinInfos[:1]
+	if err := generate(fetched, dir, snapshotPath); err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	if data, _ := os.ReadFile(snapshotPath); !strings.Contains(string(data), `"USDC.E_AVAX"`) {
+		t.Errorf("snapshot not rewritten after generation:\n%s", data)
+	}
+}
@@ -0,0 +1,114 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+const pemTypePublicKey = "PUBLIC KEY"
+
+// GenerateKey generates a new P-256 api key pair
+func GenerateKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+// EncodePrivateKeyPEM encodes key as PKCS#8 PEM
+// passphrase: if not empty, the key is encrypted with PBES2 (PBKDF2-HMAC-SHA256, AES-256-CBC),
+// which ParsePrivateKeyPEM and openssl both read
+func EncodePrivateKeyPEM(key *ecdsa.PrivateKey, passphrase []byte) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return pem.EncodeToMemory(&pem.Block{Type: pemTypePKCS8, Bytes: der}), nil
+	}
+	encrypted, err := encryptPKCS8(der, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeEncryptedPKCS8, Bytes: encrypted}), nil
+}
+
+// WritePrivateKeyFile writes key as PKCS#8 PEM readable by the owner only, it refuses to overwrite a file
+func WritePrivateKeyFile(path string, key *ecdsa.PrivateKey, passphrase []byte) error {
+	data, err := EncodePrivateKeyPEM(key, passphrase)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// MarshalPublicKeyDER encodes the public key as X.509 SubjectPublicKeyInfo DER
+func MarshalPublicKeyDER(pub *ecdsa.PublicKey) ([]byte, error) {
+	return x509.MarshalPKIXPublicKey(pub)
+}
+
+// EncodePublicKeyPEM encodes the public key as a "PUBLIC KEY" PEM block
+func EncodePublicKeyPEM(pub *ecdsa.PublicKey) ([]byte, error) {
+	der, err := MarshalPublicKeyDER(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePublicKey, Bytes: der}), nil
+}
+
+// EncodePublicKeyBase64 encodes the public key DER as a single base64 line, the PEM body without
+// header, footer and line breaks, as pasted into the cactus console when registering an api key
+func EncodePublicKeyBase64(pub *ecdsa.PublicKey) (string, error) {
+	der, err := MarshalPublicKeyDER(pub)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
+// Fingerprint returns "SHA256:" followed by the hex sha256 of the public key DER
+// Compare it with the fingerprint of the key registered on cactus to make sure requests are signed with the right key.
+func Fingerprint(pub *ecdsa.PublicKey) (string, error) {
+	der, err := MarshalPublicKeyDER(pub)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(der)
+	return "SHA256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// ParsePublicKeyPEM parses the first "PUBLIC KEY" block found in data
+func ParsePublicKeyPEM(data []byte) (*ecdsa.PublicKey, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, ErrNoPublicKey
+		}
+		if block.Type != pemTypePublicKey {
+			continue
+		}
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse public key: %w", err)
+		}
+		ecdsaPub, ok := pub.(*ecdsa.PublicKey)
+		if !ok || ecdsaPub.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("%w: got %T", ErrNotP256, pub)
+		}
+		return ecdsaPub, nil
+	}
+}
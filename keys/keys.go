@@ -0,0 +1,111 @@
+// Package keys loads the ECDSA P-256 private keys used to sign cactus api requests
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	pemTypePKCS8          = "PRIVATE KEY"
+	pemTypeEncryptedPKCS8 = "ENCRYPTED PRIVATE KEY"
+	pemTypeSEC1           = "EC PRIVATE KEY"
+)
+
+var (
+	ErrNoPrivateKey        = errors.New("no private key PEM block found")
+	ErrNoPublicKey         = errors.New("no public key PEM block found")
+	ErrPassphraseRequired  = errors.New("private key is encrypted, a passphrase is required")
+	ErrIncorrectPassphrase = errors.New("incorrect private key passphrase")
+	ErrNotP256             = errors.New("private key is not an ECDSA P-256 key")
+)
+
+// ParsePrivateKeyPEM parses the first private key found in data
+// Supported blocks: PKCS#8 "PRIVATE KEY", SEC1 "EC PRIVATE KEY", PBES2 encrypted "ENCRYPTED PRIVATE KEY"
+// and legacy "Proc-Type: 4,ENCRYPTED" SEC1 blocks. Other blocks like "EC PARAMETERS" are skipped.
+// passphrase: only used for encrypted keys, may be nil
+func ParsePrivateKeyPEM(data []byte, passphrase []byte) (*ecdsa.PrivateKey, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, ErrNoPrivateKey
+		}
+		switch block.Type {
+		case pemTypePKCS8:
+			return parsePKCS8(block.Bytes)
+		case pemTypeEncryptedPKCS8:
+			if len(passphrase) == 0 {
+				return nil, ErrPassphraseRequired
+			}
+			der, err := decryptPKCS8(block.Bytes, passphrase)
+			if err != nil {
+				return nil, err
+			}
+			if _, err = x509.ParsePKCS8PrivateKey(der); err != nil {
+				// Padding happened to look valid, but the plaintext is garbage
+				return nil, ErrIncorrectPassphrase
+			}
+			return parsePKCS8(der)
+		case pemTypeSEC1:
+			der := block.Bytes
+			// Legacy RFC 1423 encryption, still what `openssl ec -aes256` writes
+			encrypted := x509.IsEncryptedPEMBlock(block)
+			if encrypted {
+				if len(passphrase) == 0 {
+					return nil, ErrPassphraseRequired
+				}
+				var err error
+				der, err = x509.DecryptPEMBlock(block, passphrase)
+				if err != nil {
+					return nil, ErrIncorrectPassphrase
+				}
+			}
+			key, err := x509.ParseECPrivateKey(der)
+			if err != nil {
+				if encrypted {
+					return nil, ErrIncorrectPassphrase
+				}
+				return nil, fmt.Errorf("parse SEC1 private key: %w", err)
+			}
+			return checkCurve(key)
+		}
+	}
+}
+
+// LoadPrivateKeyFile reads and parses a PEM private key file, see ParsePrivateKeyPEM
+func LoadPrivateKeyFile(path string, passphrase []byte) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ParsePrivateKeyPEM(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+func parsePKCS8(der []byte) (*ecdsa.PrivateKey, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse PKCS#8 private key: %w", err)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: got %T", ErrNotP256, key)
+	}
+	return checkCurve(ecdsaKey)
+}
+
+func checkCurve(key *ecdsa.PrivateKey) (*ecdsa.PrivateKey, error) {
+	if key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("%w: got curve %s", ErrNotP256, key.Curve.Params().Name)
+	}
+	return key, nil
+}
@@ -0,0 +1,156 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := x509.EncryptPEMBlock(rand.Reader, pemTypeSEC1, sec1, []byte("secret"), x509.PEMCipherAES256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecParams := pem.EncodeToMemory(&pem.Block{Type: "EC PARAMETERS", Bytes: []byte{0x06, 0x08, 0x2a, 0x86, 0x48, 0xce, 0x3d, 0x03, 0x01, 0x07}})
+
+	tests := []struct {
+		name       string
+		data       []byte
+		passphrase string
+		wantErr    error
+	}{
+		{"pkcs8", pem.EncodeToMemory(&pem.Block{Type: pemTypePKCS8, Bytes: pkcs8}), "", nil},
+		{"sec1 after ec parameters", append(ecParams, pem.EncodeToMemory(&pem.Block{Type: pemTypeSEC1, Bytes: sec1})...), "", nil},
+		{"legacy encrypted sec1", pem.EncodeToMemory(legacy), "secret", nil},
+		{"legacy encrypted sec1 without passphrase", pem.EncodeToMemory(legacy), "", ErrPassphraseRequired},
+		{"legacy encrypted sec1 wrong passphrase", pem.EncodeToMemory(legacy), "wrong", ErrIncorrectPassphrase},
+		{"no pem", []byte("not a key"), "", ErrNoPrivateKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePrivateKeyPEM(tt.data, []byte(tt.passphrase))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParsePrivateKeyPEM() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !got.Equal(key) {
+				t.Error("ParsePrivateKeyPEM() returned a different key")
+			}
+		})
+	}
+}
+
+func TestLoadEncryptedPKCS8(t *testing.T) {
+	// Written by `openssl genpkey -algorithm EC -pkeyopt ec_paramgen_curve:P-256 -aes-256-cbc`
+	key, err := LoadPrivateKeyFile("testdata/encrypted_pkcs8.pem", []byte("correct-horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM, err := os.ReadFile("testdata/encrypted_pkcs8.pub.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(pubPEM)
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.PublicKey.Equal(pub) {
+		t.Error("decrypted key does not match the openssl public key")
+	}
+	if _, err = LoadPrivateKeyFile("testdata/encrypted_pkcs8.pem", []byte("wrong")); !errors.Is(err, ErrIncorrectPassphrase) {
+		t.Errorf("LoadPrivateKeyFile() with wrong passphrase error = %v, want %v", err, ErrIncorrectPassphrase)
+	}
+	if _, err = LoadPrivateKeyFile("testdata/encrypted_pkcs8.pem", nil); !errors.Is(err, ErrPassphraseRequired) {
+		t.Errorf("LoadPrivateKeyFile() without passphrase error = %v, want %v", err, ErrPassphraseRequired)
+	}
+}
+
+func TestRejectsOtherCurves(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ParsePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: pemTypePKCS8, Bytes: der}), nil); !errors.Is(err, ErrNotP256) {
+		t.Errorf("ParsePrivateKeyPEM() error = %v, want %v", err, ErrNotP256)
+	}
+}
+
+func TestGenerateRoundTrip(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, passphrase := range []string{"", "secret"} {
+		path := t.TempDir() + "/key.pem"
+		if err = WritePrivateKeyFile(path, key, []byte(passphrase)); err != nil {
+			t.Fatal(err)
+		}
+		if err = WritePrivateKeyFile(path, key, nil); !errors.Is(err, os.ErrExist) {
+			t.Errorf("WritePrivateKeyFile() over an existing file error = %v, want %v", err, os.ErrExist)
+		}
+		loaded, err := LoadPrivateKeyFile(path, []byte(passphrase))
+		if err != nil {
+			t.Fatalf("LoadPrivateKeyFile(passphrase %q) error = %v", passphrase, err)
+		}
+		if !loaded.Equal(key) {
+			t.Errorf("LoadPrivateKeyFile(passphrase %q) returned a different key", passphrase)
+		}
+	}
+	pubPEM, err := EncodePublicKeyPEM(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(pubPEM)
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil || !key.PublicKey.Equal(pub) {
+		t.Fatalf("EncodePublicKeyPEM() does not round trip: %v", err)
+	}
+	first, _ := Fingerprint(&key.PublicKey)
+	second, _ := Fingerprint(pub.(*ecdsa.PublicKey))
+	if first != second || len(first) != len("SHA256:")+64 {
+		t.Errorf("Fingerprint() = %s and %s", first, second)
+	}
+}
+
+func TestPBKDF2(t *testing.T) {
+	// RFC 6070 test vectors
+	tests := []struct {
+		password, salt string
+		iterations     int
+		keyLen         int
+		want           string
+	}{
+		{"password", "salt", 1, 20, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+		{"password", "salt", 4096, 20, "4b007901b765489abead49d926f721d065a429c1"},
+		{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, 25, "3d2eec4fe41c849b80c8d83662c0e44a8b291a964cf2f07038"},
+	}
+	for _, tt := range tests {
+		key, err := pbkdf2Key(sha1.New, []byte(tt.password), []byte(tt.salt), tt.iterations, tt.keyLen)
+		if err != nil || hex.EncodeToString(key) != tt.want {
+			t.Errorf("pbkdf2Key(%q, %q, %d) = %x, %v, want %s", tt.password, tt.salt, tt.iterations, key, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package keys
+
+import (
+	"crypto/hmac"
+	"encoding/binary"
+	"errors"
+	"hash"
+)
+
+// pbkdf2Key derives a key with PBKDF2 as specified in RFC 8018 section 5.2
+// Kept here instead of crypto/pbkdf2 so the module builds with toolchains older than Go 1.24.
+func pbkdf2Key(h func() hash.Hash, password []byte, salt []byte, iterations int, keyLen int) ([]byte, error) {
+	if iterations < 1 || keyLen < 1 {
+		return nil, errors.New("pbkdf2: iterations and key length must be positive")
+	}
+	prf := hmac.New(h, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+	key := make([]byte, 0, blocks*hashLen)
+	var counter [4]byte
+	u := make([]byte, hashLen)
+	for block := 1; block <= blocks; block++ {
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter[:])
+		u = prf.Sum(u[:0])
+		t := append([]byte(nil), u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen], nil
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ListSeparator joins the values of a multi valued parameter, cactus expects coin_names=BTC,LTC
+const ListSeparator = ","
+
+// canonicalParams flattens params to one value per key, joining multi values with ListSeparator
+// Keys without any value are dropped, the returned keys are sorted.
+func canonicalParams(params url.Values) ([]string, map[string]string) {
+	keys := make([]string, 0, len(params))
+	flat := make(map[string]string, len(params))
+	for key, values := range params {
+		if len(values) == 0 {
+			continue
+		}
+		keys = append(keys, key)
+		flat[key] = strings.Join(values, ListSeparator)
+	}
+	sort.Strings(keys)
+	return keys, flat
+}
+
+// EncodeSignParams encodes params to the cactus sign format, {key=[value], key2=[value1,value2]}
+// Values are not escaped, cactus signs the decoded parameters. Empty params encode to "".
+func EncodeSignParams(params url.Values) string {
+	keys, flat := canonicalParams(params)
+	if len(keys) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(key)
+		b.WriteString("=[")
+		b.WriteString(flat[key])
+		b.WriteByte(']')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// EncodeQuery encodes params to the escaped url query sent on the wire, without the leading "?"
+// Multi valued parameters are sent once, comma joined, so the server sees the same values EncodeSignParams signed.
+func EncodeQuery(params url.Values) string {
+	keys, flat := canonicalParams(params)
+	wire := make(url.Values, len(keys))
+	for _, key := range keys {
+		wire.Set(key, flat[key])
+	}
+	return wire.Encode()
+}
+
+// SignParamsFromQuery rebuilds the sign params from a received raw url query, the server side of EncodeQuery
+func SignParamsFromQuery(rawQuery string) (string, error) {
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", err
+	}
+	return EncodeSignParams(params), nil
+}
+
+func valuesFromMap(req map[string]string) url.Values {
+	values := make(url.Values, len(req))
+	for key, value := range req {
+		values.Set(key, value)
+	}
+	return values
+}
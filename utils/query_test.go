@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestEncodeQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     url.Values
+		wantQuery  string
+		wantParams string
+	}{
+		{"nil", nil, "", ""},
+		{"empty values dropped", url.Values{"b_id": {}}, "", ""},
+		{
+			"list joined",
+			url.Values{"coin_names": {"BTC", "LTC"}, "b_id": {"4a3e"}},
+			"b_id=4a3e&coin_names=BTC%2CLTC",
+			"{b_id=[4a3e], coin_names=[BTC,LTC]}",
+		},
+		{
+			"escaped",
+			url.Values{"keyword": {"a b&c=d"}, "remark": {"备注"}},
+			"keyword=a+b%26c%3Dd&remark=%E5%A4%87%E6%B3%A8",
+			"{keyword=[a b&c=d], remark=[备注]}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := EncodeQuery(tt.params)
+			if query != tt.wantQuery {
+				t.Errorf("EncodeQuery() = %q, want %q", query, tt.wantQuery)
+			}
+			signed := EncodeSignParams(tt.params)
+			if signed != tt.wantParams {
+				t.Errorf("EncodeSignParams() = %q, want %q", signed, tt.wantParams)
+			}
+			// The server rebuilds the sign params from the wire query, both sides must agree
+			received, err := SignParamsFromQuery(query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if received != signed {
+				t.Errorf("SignParamsFromQuery(EncodeQuery()) = %q, want %q", received, signed)
+			}
+		})
+	}
+}
+
+func TestEncodeGetParamsEmpty(t *testing.T) {
+	if got := EncodeGetParams(map[string]string{}); got != "" {
+		t.Errorf("EncodeGetParams() = %q, want empty", got)
+	}
+}
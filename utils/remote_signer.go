@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Remote signing protocol
+// The client opens a connection to the signing daemon (a unix socket by default) and writes one json line
+//
+//	{"key_id":"<api key id>","digest":"<base64 sha256 digest>"}
+//
+// the daemon answers with one json line and closes the connection
+//
+//	{"signature":"<base64 ASN.1 DER signature>"} or {"error":"<reason>"}
+
+// RemoteSignRequest is the request line of the remote signing protocol
+type RemoteSignRequest struct {
+	KeyID  string `json:"key_id"`
+	Digest string `json:"digest"`
+}
+
+// RemoteSignResponse is the response line of the remote signing protocol
+type RemoteSignResponse struct {
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// RemoteSigner delegates signing to a local daemon, so the key never enters this process
+type RemoteSigner struct {
+	Network string        // "unix" unless set
+	Address string        // socket path
+	KeyID   string        // sent to the daemon so it can pick the key, may be empty
+	Timeout time.Duration // per signature, 0 for no timeout besides the context
+}
+
+// NewRemoteSigner creates a signer talking to the daemon listening on the unix socket socketPath
+func NewRemoteSigner(socketPath string, keyId string) *RemoteSigner {
+	return &RemoteSigner{Network: "unix", Address: socketPath, KeyID: keyId, Timeout: 10 * time.Second}
+}
+
+func (s *RemoteSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	network := s.Network
+	if network == "" {
+		network = "unix"
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, s.Address)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	req := RemoteSignRequest{KeyID: s.KeyID, Digest: base64.StdEncoding.EncodeToString(digest)}
+	if err = json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	var resp RemoteSignResponse
+	if err = json.NewDecoder(bufio.NewReader(conn)).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("remote signer: %s", resp.Error)
+	}
+	signature, err := base64.StdEncoding.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	return signature, nil
+}
+
+// ServeRemoteSigner answers remote signing requests on l with signer until l is closed
+// It is the daemon side of RemoteSigner, wrap an HSM or KMS backed Signer to keep keys out of the client.
+func ServeRemoteSigner(l net.Listener, signer Signer) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go serveRemoteSignConn(conn, signer)
+	}
+}
+
+func serveRemoteSignConn(conn net.Conn, signer Signer) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(30 * time.Second))
+	var req RemoteSignRequest
+	var resp RemoteSignResponse
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&req); err != nil {
+		resp.Error = "bad request: " + err.Error()
+	} else if digest, err := base64.StdEncoding.DecodeString(req.Digest); err != nil {
+		resp.Error = "bad digest: " + err.Error()
+	} else if signature, err := signer.SignDigest(context.Background(), digest); err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Signature = base64.StdEncoding.EncodeToString(signature)
+	}
+	_ = json.NewEncoder(conn).Encode(resp)
+}
@@ -1,12 +1,12 @@
 package utils
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
-	"slices"
 	"time"
 )
 
@@ -15,7 +15,12 @@ const RequestContentType = "application/json"
 const ServiceName = "api"
 
 func GetCurrentGmtTime() string {
-	currentTime := time.Now().UTC().Format(time.RFC1123)
+	return FormatDate(time.Now())
+}
+
+// FormatDate formats t for the Date header and the sign string, RFC1123 in GMT
+func FormatDate(t time.Time) string {
+	currentTime := t.UTC().Format(time.RFC1123)
 	currentTime = currentTime[0:len(currentTime)-3] + "GMT"
 	return currentTime
 }
@@ -45,13 +50,7 @@ func GenerateSignString(RequestMethod string, body []byte, XApiKey string, XApiN
 // Sign signs the string
 // signReq: the string to be signed
 func Sign(signReq []byte, key *ecdsa.PrivateKey) ([]byte, error) {
-	cryptoRand := rand.Reader
-	hash := sha256.Sum256(signReq)
-	sign, err := ecdsa.SignASN1(cryptoRand, key, hash[:])
-	if err != nil {
-		return nil, err
-	}
-	return sign, nil
+	return SignWithSigner(context.Background(), signReq, NewECDSASigner(key))
 }
 
 // GenerateAuthorizationHeader generates the authorization header
@@ -59,43 +58,19 @@ func Sign(signReq []byte, key *ecdsa.PrivateKey) ([]byte, error) {
 // ApiKeyId: the api key id
 // key: the private key
 func GenerateAuthorizationHeader(signReq []byte, ApiKeyId string, key *ecdsa.PrivateKey) (string, error) {
-	signature, err := Sign(signReq, key)
-	if err != nil {
-		return "", err
-	}
-	return fmt.Sprintf("%s %s:%s", ServiceName, ApiKeyId, base64.StdEncoding.EncodeToString(signature)), nil
+	return GenerateAuthorizationHeaderWithSigner(context.Background(), signReq, ApiKeyId, NewECDSASigner(key))
 }
 
 // EncodeGetParams encodes the post params, to the cactus format, like {param_name=[param_value], param_name2=[param_value2]} example:{b_id=[4a3e2fb40faa4b9d94480559ac01e8de], coin_names=[BTC,LTC], hide_no_coin_wallet=[false], total_market_order=[0]}
+// Deprecated: use EncodeSignParams, which handles multi valued parameters
 func EncodeGetParams(req map[string]string) string {
-	result := ""
-	keys := make([]string, 0)
-	for k := range req {
-		keys = append(keys, k)
-	}
-	slices.Sort(keys)
-	for _, key := range keys {
-		result += key + "=[" + req[key] + "], "
-	}
-	// remove the last ", "
-	return "{" + result[0:len(result)-2] + "}"
+	return EncodeSignParams(valuesFromMap(req))
 }
 
+// EncodeGetQuery encodes the params to the url query
+// Deprecated: use EncodeQuery, which handles multi valued parameters
 func EncodeGetQuery(req map[string]string) string {
-	result := ""
-	keys := make([]string, 0)
-	for k := range req {
-		keys = append(keys, k)
-	}
-	slices.Sort(keys)
-	for _, key := range keys {
-		result += key + "=" + req[key] + "&"
-	}
-	// remove the last "&"
-	if len(result) == 0 {
-		return result
-	}
-	return result[0 : len(result)-1]
+	return EncodeQuery(valuesFromMap(req))
 }
 
 func GenerateUuid() string {
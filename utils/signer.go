@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrNoSigner is returned when a request has to be signed but no key or signer was configured
+var ErrNoSigner = errors.New("no signer configured")
+
+// Signer signs the sha256 digest of a sign string
+// Implementations must return an ASN.1 DER encoded ECDSA P-256 signature, the format cactus expects.
+// The key may live anywhere, in memory, in an HSM, in a cloud KMS or behind a local signing daemon.
+type Signer interface {
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// ECDSASigner signs with an in memory private key
+type ECDSASigner struct {
+	Key *ecdsa.PrivateKey
+}
+
+// NewECDSASigner wraps an in memory private key
+func NewECDSASigner(key *ecdsa.PrivateKey) *ECDSASigner {
+	return &ECDSASigner{Key: key}
+}
+
+func (s *ECDSASigner) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	if s.Key == nil {
+		return nil, ErrNoSigner
+	}
+	return ecdsa.SignASN1(rand.Reader, s.Key, digest)
+}
+
+// CryptoSigner signs with any crypto.Signer backed by an ECDSA key, e.g. a PKCS#11 or KMS client
+type CryptoSigner struct {
+	Signer crypto.Signer
+}
+
+// NewCryptoSigner wraps a crypto.Signer
+func NewCryptoSigner(signer crypto.Signer) *CryptoSigner {
+	return &CryptoSigner{Signer: signer}
+}
+
+func (s *CryptoSigner) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	if s.Signer == nil {
+		return nil, ErrNoSigner
+	}
+	if _, ok := s.Signer.Public().(*ecdsa.PublicKey); !ok {
+		return nil, fmt.Errorf("crypto signer holds a %T, cactus requires an ECDSA key", s.Signer.Public())
+	}
+	return s.Signer.Sign(rand.Reader, digest, crypto.SHA256)
+}
+
+// SignWithSigner signs the string with a Signer
+// signReq: the string to be signed
+func SignWithSigner(ctx context.Context, signReq []byte, signer Signer) ([]byte, error) {
+	if signer == nil {
+		return nil, ErrNoSigner
+	}
+	hash := sha256.Sum256(signReq)
+	return signer.SignDigest(ctx, hash[:])
+}
+
+// GenerateAuthorizationHeaderWithSigner generates the authorization header using a Signer
+// signReq: the string to be signed
+// ApiKeyId: the api key id
+// signer: the signer holding the api private key
+func GenerateAuthorizationHeaderWithSigner(ctx context.Context, signReq []byte, ApiKeyId string, signer Signer) (string, error) {
+	signature, err := SignWithSigner(ctx, signReq, signer)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s:%s", ServiceName, ApiKeyId, base64.StdEncoding.EncodeToString(signature)), nil
+}
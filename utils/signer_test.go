@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSigners(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket := filepath.Join(t.TempDir(), "signer.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() { _ = ServeRemoteSigner(listener, NewECDSASigner(key)) }()
+
+	signers := map[string]Signer{
+		"ecdsa":  NewECDSASigner(key),
+		"crypto": NewCryptoSigner(key),
+		"remote": NewRemoteSigner(socket, "key-id"),
+	}
+	signReq := []byte(GenerateGetSign)
+	hash := sha256.Sum256(signReq)
+	for name, signer := range signers {
+		t.Run(name, func(t *testing.T) {
+			header, err := GenerateAuthorizationHeaderWithSigner(context.Background(), signReq, "key-id", signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prefix := ServiceName + " key-id:"
+			if !strings.HasPrefix(header, prefix) {
+				t.Fatalf("header = %s, want prefix %s", header, prefix)
+			}
+			signature, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(header, prefix))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ecdsa.VerifyASN1(&key.PublicKey, hash[:], signature) {
+				t.Error("signature does not verify")
+			}
+		})
+	}
+}
+
+func TestNoSigner(t *testing.T) {
+	if _, err := SignWithSigner(context.Background(), []byte("x"), nil); err != ErrNoSigner {
+		t.Errorf("SignWithSigner(nil) error = %v, want %v", err, ErrNoSigner)
+	}
+	if _, err := NewRemoteSigner(filepath.Join(t.TempDir(), "missing.sock"), "").SignDigest(context.Background(), make([]byte, 32)); err == nil {
+		t.Error("RemoteSigner without daemon succeeded")
+	}
+}
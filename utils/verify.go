@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned by Verifier.VerifyAuthorizationHeader, all of them mean the request must be rejected
+var (
+	ErrMalformedAuthorization = errors.New("malformed authorization header")
+	ErrUnknownKeyID           = errors.New("unknown api key id")
+	ErrDateSkew               = errors.New("request date outside the allowed clock skew")
+	ErrContentHashMismatch    = errors.New("content sha256 does not match the body")
+	ErrInvalidSignature       = errors.New("invalid signature")
+	ErrNonceReplayed          = errors.New("nonce already used")
+	ErrBodyTooLarge           = errors.New("request body too large")
+)
+
+// DefaultMaxBodySize is the largest request body a Verifier reads when MaxBodySize is not set
+const DefaultMaxBodySize = 1 << 20
+
+// PublicKeyLookup returns the public key registered for an api key id
+// Return a nil key or an error for unknown ids.
+type PublicKeyLookup func(keyID string) (*ecdsa.PublicKey, error)
+
+// Verifier checks cactus authorization headers, the server side of GenerateAuthorizationHeader
+// It remembers the nonces of accepted requests for twice MaxSkew, the longest time a captured
+// request could still pass the date check, so a Verifier must be shared by all requests it guards.
+type Verifier struct {
+	Lookup  PublicKeyLookup
+	MaxSkew time.Duration
+	// Now is the verifier clock, nil uses time.Now
+	Now func() time.Time
+	// MaxBodySize bounds the body read before the request is authenticated, 0 uses DefaultMaxBodySize
+	MaxBodySize int64
+
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	pruned time.Time
+}
+
+// NewVerifier creates a verifier accepting requests dated at most maxSkew away from now
+func NewVerifier(lookup PublicKeyLookup, maxSkew time.Duration) *Verifier {
+	return &Verifier{Lookup: lookup, MaxSkew: maxSkew}
+}
+
+// VerifyAuthorizationHeader authenticates r and returns the api key id it was signed with
+// It rebuilds the sign string from the method, path, query, x-api-key, x-api-nonce and Date headers,
+// checks Content-SHA256 against the body, validates the ECDSA ASN.1 signature and rejects replayed nonces.
+// The body is read and replaced, so r can still be forwarded or handled afterwards.
+func (v *Verifier) VerifyAuthorizationHeader(r *http.Request) (string, error) {
+	credentials, ok := strings.CutPrefix(r.Header.Get("Authorization"), ServiceName+" ")
+	if !ok {
+		return "", fmt.Errorf("%w: scheme is not %s", ErrMalformedAuthorization, ServiceName)
+	}
+	keyID, signature, ok := strings.Cut(credentials, ":")
+	if !ok || keyID == "" {
+		return "", ErrMalformedAuthorization
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return "", ErrMalformedAuthorization
+	}
+	nonce := r.Header.Get("x-api-nonce")
+	if nonce == "" {
+		return "", fmt.Errorf("%w: missing x-api-nonce", ErrMalformedAuthorization)
+	}
+	date := r.Header.Get("Date")
+	sent, err := http.ParseTime(date)
+	if err != nil {
+		return "", fmt.Errorf("%w: missing or malformed Date", ErrMalformedAuthorization)
+	}
+	now := v.now()
+	if skew := sent.Sub(now); skew > v.MaxSkew || -skew > v.MaxSkew {
+		return "", fmt.Errorf("%w: %s", ErrDateSkew, skew.Round(time.Second))
+	}
+
+	body, err := readBody(r, v.maxBodySize())
+	if err != nil {
+		return "", err
+	}
+	contentHash := r.Header.Get("Content-SHA256")
+	if body != nil || contentHash != "" {
+		sum := sha256.Sum256(body)
+		if contentHash != base64.StdEncoding.EncodeToString(sum[:]) {
+			return "", ErrContentHashMismatch
+		}
+	}
+	params, err := SignParamsFromQuery(r.URL.RawQuery)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed query", ErrMalformedAuthorization)
+	}
+	signString := GenerateSignString(r.Method, body, r.Header.Get("x-api-key"), nonce, r.URL.Path, params, date)
+
+	pub, err := v.Lookup(keyID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnknownKeyID, err)
+	}
+	if pub == nil {
+		return "", ErrUnknownKeyID
+	}
+	digest := sha256.Sum256([]byte(signString))
+	if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+		return "", ErrInvalidSignature
+	}
+	// Only authentic requests are remembered, so forged ones can not burn nonces
+	if !v.useNonce(nonce, now) {
+		return "", ErrNonceReplayed
+	}
+	return keyID, nil
+}
+
+func (v *Verifier) maxBodySize() int64 {
+	if v.MaxBodySize > 0 {
+		return v.MaxBodySize
+	}
+	return DefaultMaxBodySize
+}
+
+func (v *Verifier) now() time.Time {
+	if v.Now != nil {
+		return v.Now()
+	}
+	return time.Now()
+}
+
+// useNonce records nonce, returns false if it was already seen
+func (v *Verifier) useNonce(nonce string, now time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.nonces == nil {
+		v.nonces = map[string]time.Time{}
+	}
+	// Sweep expired nonces at most once per MaxSkew
+	if now.Sub(v.pruned) > v.MaxSkew {
+		for seen, at := range v.nonces {
+			if now.Sub(at) > 2*v.MaxSkew {
+				delete(v.nonces, seen)
+			}
+		}
+		v.pruned = now
+	}
+	if _, ok := v.nonces[nonce]; ok {
+		return false
+	}
+	v.nonces[nonce] = now
+	return true
+}
+
+// readBody reads and restores the request body, an empty body is returned as nil like the client signs it
+// Bodies longer than limit are refused without reading past the limit.
+func readBody(r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	if r.ContentLength > limit {
+		r.Body.Close()
+		return nil, fmt.Errorf("%w: %d bytes", ErrBodyTooLarge, r.ContentLength)
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: over %d bytes", ErrBodyTooLarge, limit)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) == 0 {
+		return nil, nil
+	}
+	return body, nil
+}
@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// signRequest signs r the way the cactus client does
+func signRequest(t *testing.T, r *http.Request, body []byte, key *ecdsa.PrivateKey, nonce string, date time.Time) {
+	t.Helper()
+	currentTime := date.UTC().Format(http.TimeFormat)
+	params, err := SignParamsFromQuery(r.URL.RawQuery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signString := GenerateSignString(r.Method, body, "api-key", nonce, r.URL.Path, params, currentTime)
+	header, err := GenerateAuthorizationHeader([]byte(signString), "key-1", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != nil {
+		sum := sha256.Sum256(body)
+		r.Header.Set("Content-SHA256", base64.StdEncoding.EncodeToString(sum[:]))
+	}
+	r.Header.Set("x-api-key", "api-key")
+	r.Header.Set("x-api-nonce", nonce)
+	r.Header.Set("Date", currentTime)
+	r.Header.Set("Authorization", header)
+}
+
+func TestVerifyAuthorizationHeader(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	verifier := NewVerifier(func(keyID string) (*ecdsa.PublicKey, error) {
+		if keyID == "key-1" {
+			return &key.PublicKey, nil
+		}
+		return nil, nil
+	}, time.Minute)
+	verifier.Now = func() time.Time { return now }
+
+	query := "/custody/v1/api/wallets?" + EncodeQuery(url.Values{"coin_names": {"BTC", "LTC"}, "keyword": {"a b&c"}})
+	body := []byte(`{"wallet_type":"DEFI","number":1}`)
+	post := "/custody/v1/api/projects/b/wallets/create"
+
+	tests := []struct {
+		name    string
+		request func() *http.Request
+		wantErr error
+	}{
+		{"get with query", func() *http.Request {
+			r := httptest.NewRequest(http.MethodGet, query, nil)
+			signRequest(t, r, nil, key, "n1", now)
+			return r
+		}, nil},
+		{"post", func() *http.Request {
+			r := httptest.NewRequest(http.MethodPost, post, bytes.NewReader(body))
+			signRequest(t, r, body, key, "n2", now.Add(-30*time.Second))
+			return r
+		}, nil},
+		{"replayed nonce", func() *http.Request {
+			r := httptest.NewRequest(http.MethodGet, query, nil)
+			signRequest(t, r, nil, key, "n1", now)
+			return r
+		}, ErrNonceReplayed},
+		{"date too old", func() *http.Request {
+			r := httptest.NewRequest(http.MethodGet, query, nil)
+			signRequest(t, r, nil, key, "n3", now.Add(-2*time.Minute))
+			return r
+		}, ErrDateSkew},
+		{"tampered body", func() *http.Request {
+			r := httptest.NewRequest(http.MethodPost, post, bytes.NewReader([]byte(`{"wallet_type":"DEFI","number":9}`)))
+			signRequest(t, r, body, key, "n4", now)
+			return r
+		}, ErrContentHashMismatch},
+		{"tampered query", func() *http.Request {
+			r := httptest.NewRequest(http.MethodGet, query, nil)
+			signRequest(t, r, nil, key, "n5", now)
+			r.URL.RawQuery = "coin_names=ETH"
+			return r
+		}, ErrInvalidSignature},
+		{"wrong key", func() *http.Request {
+			r := httptest.NewRequest(http.MethodGet, query, nil)
+			signRequest(t, r, nil, other, "n6", now)
+			return r
+		}, ErrInvalidSignature},
+		{"unknown key id", func() *http.Request {
+			r := httptest.NewRequest(http.MethodGet, query, nil)
+			signRequest(t, r, nil, key, "n7", now)
+			r.Header.Set("Authorization", "api key-2:"+r.Header.Get("Authorization")[len("api key-1:"):])
+			return r
+		}, ErrUnknownKeyID},
+		{"missing header", func() *http.Request {
+			return httptest.NewRequest(http.MethodGet, query, nil)
+		}, ErrMalformedAuthorization},
+		{"missing scheme", func() *http.Request {
+			r := httptest.NewRequest(http.MethodGet, query, nil)
+			signRequest(t, r, nil, key, "n8", now)
+			r.Header.Set("Authorization", r.Header.Get("Authorization")[len("api "):])
+			return r
+		}, ErrMalformedAuthorization},
+		{"other scheme", func() *http.Request {
+			r := httptest.NewRequest(http.MethodGet, query, nil)
+			signRequest(t, r, nil, key, "n9", now)
+			r.Header.Set("Authorization", "Bearer "+r.Header.Get("Authorization")[len("api "):])
+			return r
+		}, ErrMalformedAuthorization},
+		{"body too large", func() *http.Request {
+			large := bytes.Repeat([]byte("a"), DefaultMaxBodySize+1)
+			r := httptest.NewRequest(http.MethodPost, post, bytes.NewReader(large))
+			signRequest(t, r, large, key, "n10", now)
+			return r
+		}, ErrBodyTooLarge},
+		{"body too large without length", func() *http.Request {
+			large := bytes.Repeat([]byte("a"), DefaultMaxBodySize+1)
+			r := httptest.NewRequest(http.MethodPost, post, io.MultiReader(bytes.NewReader(large)))
+			r.ContentLength = -1
+			signRequest(t, r, large, key, "n11", now)
+			return r
+		}, ErrBodyTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyID, err := verifier.VerifyAuthorizationHeader(tt.request())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyAuthorizationHeader() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && keyID != "key-1" {
+				t.Errorf("VerifyAuthorizationHeader() key id = %q, want key-1", keyID)
+			}
+		})
+	}
+}
+
+func TestVerifyRestoresBody(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := NewVerifier(func(string) (*ecdsa.PublicKey, error) { return &key.PublicKey, nil }, time.Minute)
+	body := []byte(`{"remark":"hello"}`)
+	r := httptest.NewRequest(http.MethodPost, "/custody/v1/api/projects/b/wallets/w/details/1", bytes.NewReader(body))
+	signRequest(t, r, body, key, "n1", time.Now())
+	if _, err = verifier.VerifyAuthorizationHeader(r); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := io.ReadAll(r.Body)
+	if !bytes.Equal(got, body) {
+		t.Errorf("body after verification = %q, want %q", got, body)
+	}
+}